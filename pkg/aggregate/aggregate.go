@@ -0,0 +1,269 @@
+// Package aggregate rolls hourly data points up into daily, multi-hour and weekly data points
+// shaped like the API's daily block
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// ErrInvalidHours is returned by GroupByHours and Hours when n hours do not divide the day evenly
+var ErrInvalidHours = errors.New("hours must be a positive divisor of 24")
+
+// Window is a span of local time and the data points starting in it
+type Window struct {
+	Start  time.Time
+	End    time.Time
+	Points []models.DataPoint
+}
+
+// meanFields are averaged over a window
+var meanFields = []string{
+	"precipIntensity", "precipIntensityError", "dewPoint", "humidity", "pressure",
+	"windSpeed", "cloudCover", "visibility", "ozone", "smoke",
+}
+
+// maxFields keep their largest value over a window
+var maxFields = []string{"precipProbability", "windGust", "uvIndex", "fireIndex"}
+
+// sumFields are totalled over a window
+var sumFields = []string{"precipAccumulation", "liquidAccumulation", "snowAccumulation", "iceAccumulation"}
+
+// GroupByDay splits the points into local calendar days of loc. Days with a daylight saving
+// transition are 23 or 25 hours long.
+func GroupByDay(points []models.DataPoint, loc *time.Location) []Window {
+	return group(points, loc, midnight, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) })
+}
+
+// GroupByHours splits the points into windows of n local hours aligned to midnight, e.g. 00–03, 03–06 for n = 3.
+// n must divide 24; other values return ErrInvalidHours.
+func GroupByHours(points []models.DataPoint, loc *time.Location, n int) ([]Window, error) {
+	if n <= 0 || 24%n != 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidHours, n)
+	}
+	start := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()-t.Hour()%n, 0, 0, 0, t.Location())
+	}
+	next := func(t time.Time) time.Time {
+		end := start(t.Add(time.Duration(n) * time.Hour))
+		if !end.After(t) {
+			// A repeated hour at the end of daylight saving time
+			end = start(t.Add(time.Duration(n+1) * time.Hour))
+		}
+		return end
+	}
+	return group(points, loc, start, next), nil
+}
+
+// GroupByWeek splits the points into local weeks starting on Monday
+func GroupByWeek(points []models.DataPoint, loc *time.Location) []Window {
+	start := func(t time.Time) time.Time {
+		day := midnight(t)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return group(points, loc, start, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) })
+}
+
+// Days rolls the points up into one data point per local day. The high and low follow the API:
+// the high is the warmest point between 06:00 and 18:00, the low the coldest between 18:00 and 06:00
+// the next morning, which is looked up in points when available.
+func Days(points []models.DataPoint, loc *time.Location) []models.DataPoint {
+	points = sorted(points)
+	var days []models.DataPoint
+	for _, w := range GroupByDay(points, loc) {
+		day := Summarize(w)
+		daytime := between(points, clock(w.Start, 6), clock(w.Start, 18))
+		overnight := between(points, clock(w.Start, 18), clock(w.End, 6))
+		setExtreme(&day, daytime, "temperature", "temperatureHigh", math.Max)
+		setExtreme(&day, overnight, "temperature", "temperatureLow", math.Min)
+		setExtreme(&day, daytime, "apparentTemperature", "apparentTemperatureHigh", math.Max)
+		setExtreme(&day, overnight, "apparentTemperature", "apparentTemperatureLow", math.Min)
+		days = append(days, day)
+	}
+	return days
+}
+
+// Hours rolls the points up into one data point per n local hours; n must divide 24
+func Hours(points []models.DataPoint, loc *time.Location, n int) ([]models.DataPoint, error) {
+	windows, err := GroupByHours(sorted(points), loc, n)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeAll(windows), nil
+}
+
+// Weeks rolls the points up into one data point per local week starting on Monday
+func Weeks(points []models.DataPoint, loc *time.Location) []models.DataPoint {
+	return summarizeAll(GroupByWeek(sorted(points), loc))
+}
+
+// Daily rolls the forecast's hourly block up into a daily block in the forecast's timezone
+func Daily(forecast *models.ForecastResponse) (*models.DataBlock, error) {
+	if forecast.Hourly == nil {
+		return nil, models.ErrNoHourly
+	}
+	days := Days(forecast.Hourly.Data, forecast.Location())
+	return &models.DataBlock{Icon: DominantIcon(days), Data: days}, nil
+}
+
+// Hourly merges the hourly points of several responses, such as consecutive TimeMachine days,
+// into one time-ordered series without duplicates
+func Hourly(responses ...*models.ForecastResponse) []models.DataPoint {
+	seen := make(map[int64]bool)
+	var points []models.DataPoint
+	for _, response := range responses {
+		if response == nil || response.Hourly == nil {
+			continue
+		}
+		for _, point := range response.Hourly.Data {
+			if !seen[point.Time] {
+				seen[point.Time] = true
+				points = append(points, point)
+			}
+		}
+	}
+	return sorted(points)
+}
+
+// Summarize rolls a window up into a single data point shaped like a daily point: means, maxima
+// and totals, the calendar minimum and maximum temperatures with their times, and the dominant icon.
+// The high and low are the window's maximum and minimum; Days refines them the way the API does.
+// Fields with no real value in the window, and fields only found on hourly points, are absent.
+func Summarize(w Window) models.DataPoint {
+	var day models.DataPoint
+	day.SetLocation(w.Start.Location())
+	day.Time = w.Start.Unix()
+	day.Icon = DominantIcon(w.Points)
+	day.SetAbsent("temperature", "apparentTemperature", "nearestStormDistance", "nearestStormBearing",
+		"moonPhase", "sunriseTime", "sunsetTime", "dawnTime", "duskTime")
+
+	for _, name := range meanFields {
+		set(&day, name)(Mean(w.Points, name))
+	}
+	for _, name := range maxFields {
+		set(&day, name)(Max(w.Points, name))
+	}
+	for _, name := range sumFields {
+		set(&day, name)(Sum(w.Points, name))
+	}
+	set(&day, "windBearing")(meteorology.MeanBearing(w.Points))
+
+	setExtreme(&day, w.Points, "temperature", "temperatureMax", math.Max)
+	setExtreme(&day, w.Points, "temperature", "temperatureMin", math.Min)
+	setExtreme(&day, w.Points, "apparentTemperature", "apparentTemperatureMax", math.Max)
+	setExtreme(&day, w.Points, "apparentTemperature", "apparentTemperatureMin", math.Min)
+	setExtreme(&day, w.Points, "temperature", "temperatureHigh", math.Max)
+	setExtreme(&day, w.Points, "temperature", "temperatureLow", math.Min)
+	setExtreme(&day, w.Points, "apparentTemperature", "apparentTemperatureHigh", math.Max)
+	setExtreme(&day, w.Points, "apparentTemperature", "apparentTemperatureLow", math.Min)
+
+	if wettest, ok := extreme(w.Points, "precipIntensity", math.Max); ok {
+		intensity, _ := wettest.Value("precipIntensity")
+		day.SetValue("precipIntensityMax", intensity)
+		day.SetValue("precipIntensityMaxTime", float64(wettest.Time))
+		day.PrecipType = wettest.PrecipType
+	} else {
+		day.SetAbsent("precipIntensityMax", "precipIntensityMaxTime")
+	}
+	return day
+}
+
+func summarizeAll(windows []Window) []models.DataPoint {
+	var points []models.DataPoint
+	for _, w := range windows {
+		points = append(points, Summarize(w))
+	}
+	return points
+}
+
+// group splits time-ordered points into windows; start returns the start of the window containing t
+// and next the start of the window after the one starting at t
+func group(points []models.DataPoint, loc *time.Location, start, next func(time.Time) time.Time) []Window {
+	var windows []Window
+	for _, point := range sorted(points) {
+		t := time.Unix(point.Time, 0).In(loc)
+		if len(windows) == 0 || !t.Before(windows[len(windows)-1].End) {
+			begin := start(t)
+			windows = append(windows, Window{Start: begin, End: next(begin)})
+		}
+		w := &windows[len(windows)-1]
+		w.Points = append(w.Points, point)
+	}
+	return windows
+}
+
+// set returns a function storing a (value, ok) result in the named field, or marking it absent
+func set(day *models.DataPoint, name string) func(float64, bool) {
+	return func(value float64, ok bool) {
+		if ok {
+			day.SetValue(name, value)
+		} else {
+			day.SetAbsent(name)
+		}
+	}
+}
+
+// setExtreme stores the extreme of the source field in target and its time in target+"Time"
+func setExtreme(day *models.DataPoint, points []models.DataPoint, source, target string, pick func(a, b float64) float64) {
+	point, ok := extreme(points, source, pick)
+	if !ok {
+		day.SetAbsent(target, target+"Time")
+		return
+	}
+	value, _ := point.Value(source)
+	day.SetValue(target, value)
+	day.SetValue(target+"Time", float64(point.Time))
+}
+
+// extreme returns the first point whose value of the field is selected by pick (math.Max or math.Min)
+func extreme(points []models.DataPoint, name string, pick func(a, b float64) float64) (models.DataPoint, bool) {
+	var best models.DataPoint
+	var bestValue float64
+	found := false
+	for _, p := range points {
+		value, ok := p.Value(name)
+		if !ok {
+			continue
+		}
+		if !found || (pick(value, bestValue) == value && value != bestValue) {
+			best, bestValue, found = p, value, true
+		}
+	}
+	return best, found
+}
+
+// between returns the points with begin <= time < end
+func between(points []models.DataPoint, begin, end time.Time) []models.DataPoint {
+	var window []models.DataPoint
+	for _, p := range points {
+		if p.Time >= begin.Unix() && p.Time < end.Unix() {
+			window = append(window, p)
+		}
+	}
+	return window
+}
+
+func sorted(points []models.DataPoint) []models.DataPoint {
+	if sort.SliceIsSorted(points, func(i, j int) bool { return points[i].Time < points[j].Time }) {
+		return points
+	}
+	result := make([]models.DataPoint, len(points))
+	copy(result, points)
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Time < result[j].Time })
+	return result
+}
+
+func midnight(t time.Time) time.Time {
+	return clock(t, 0)
+}
+
+// clock returns the given local hour of t's day
+func clock(t time.Time, hour int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, t.Location())
+}
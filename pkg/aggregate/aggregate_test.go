@@ -0,0 +1,135 @@
+package aggregate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/aggregate"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyMatchesForecastDaily(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, paris)
+	forecast := synthetic.Generate(48.85, 2.35, start, synthetic.WithTimezone("Europe/Paris"), synthetic.WithHours(24*4))
+
+	daily, err := aggregate.Daily(forecast)
+	require.NoError(t, err)
+	require.Len(t, daily.Data, 4)
+
+	// The last day has no following morning, so only the first three are compared in full
+	for i := 0; i < 3; i++ {
+		got, want := daily.Data[i], forecast.Daily.Data[i]
+		require.Equal(t, want.Time, got.Time)
+		require.Equal(t, want.TemperatureHigh, got.TemperatureHigh)
+		require.Equal(t, want.TemperatureHighTime, got.TemperatureHighTime)
+		require.Equal(t, want.TemperatureLow, got.TemperatureLow)
+		require.Equal(t, want.TemperatureMin, got.TemperatureMin)
+		require.Equal(t, want.TemperatureMax, got.TemperatureMax)
+		require.Equal(t, want.PrecipIntensityMax, got.PrecipIntensityMax)
+		require.Equal(t, want.WindGust, got.WindGust)
+		require.InDelta(t, want.PrecipAccumulation, got.PrecipAccumulation, 1e-3)
+		require.InDelta(t, want.Humidity, got.Humidity, 0.01)
+		require.InDelta(t, want.Pressure, got.Pressure, 0.01)
+		require.True(t, got.IsMissing("temperature"))
+		require.True(t, got.IsMissing("sunriseTime"))
+	}
+}
+
+func TestDailyRequiresHourly(t *testing.T) {
+	_, err := aggregate.Daily(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoHourly)
+}
+
+func TestGroupingAcrossDaylightSaving(t *testing.T) {
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	start := time.Date(2023, 11, 4, 0, 0, 0, 0, toronto)
+	points := synthetic.Generate(43.65, -79.38, start, synthetic.WithTimezone("America/Toronto"), synthetic.WithHours(72)).Hourly.Data
+
+	days := aggregate.GroupByDay(points, toronto)
+	require.Len(t, days, 3)
+	require.Len(t, days[0].Points, 24)
+	require.Len(t, days[1].Points, 25)
+	require.Len(t, days[2].Points, 23)
+	require.Equal(t, 0, days[2].Start.Hour())
+
+	windows, err := aggregate.GroupByHours(points, toronto, 3)
+	require.NoError(t, err)
+	require.Len(t, windows, 24)
+	require.Len(t, windows[8].Points, 4) // 00:00–03:00 on November 5 includes the repeated hour
+	for _, w := range windows {
+		require.Zero(t, w.Start.Hour()%3)
+	}
+
+	threeHourly, err := aggregate.Hours(points, toronto, 3)
+	require.NoError(t, err)
+	require.Len(t, threeHourly, 24)
+
+	for _, n := range []int{0, -3, 5, 48} {
+		_, err = aggregate.GroupByHours(points, toronto, n)
+		require.ErrorIs(t, err, aggregate.ErrInvalidHours, "n = %d", n)
+		_, err = aggregate.Hours(points, toronto, n)
+		require.ErrorIs(t, err, aggregate.ErrInvalidHours, "n = %d", n)
+	}
+
+	weeks := aggregate.GroupByWeek(points, toronto)
+	require.Len(t, weeks, 2)
+	require.Equal(t, time.Monday, weeks[1].Start.Weekday())
+	require.Len(t, aggregate.Weeks(points, toronto), 2)
+}
+
+func TestHourlyMergesTimeMachineDays(t *testing.T) {
+	day := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	first := synthetic.TimeMachine(51.5, -0.12, day, synthetic.WithTimezone("UTC"))
+	second := synthetic.TimeMachine(51.5, -0.12, day.AddDate(0, 0, 1), synthetic.WithTimezone("UTC"))
+
+	points := aggregate.Hourly(second, first, first)
+	require.Len(t, points, 48)
+	require.Less(t, points[0].Time, points[47].Time)
+
+	days := aggregate.Days(points, time.UTC)
+	require.Len(t, days, 2)
+	require.Equal(t, first.Daily.Data[0].TemperatureMax, days[0].TemperatureMax)
+}
+
+func TestStatistics(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var points []models.DataPoint
+	for i, temperature := range []float64{10, 12, 14, 16, 18} {
+		points = append(points, models.DataPoint{
+			Time:               start.Add(time.Duration(i) * time.Hour).Unix(),
+			Temperature:        temperature,
+			WindGust:           float64(i),
+			PrecipAccumulation: 0.1,
+			Icon:               []string{"clear-night", "clear-day", "rain", "rain", "cloudy"}[i],
+		})
+	}
+
+	median, ok := aggregate.Percentile(points, "temperature", 50)
+	require.True(t, ok)
+	require.Equal(t, 14.0, median)
+	p90, ok := aggregate.Percentile(points, "temperature", 90)
+	require.True(t, ok)
+	require.InDelta(t, 17.2, p90, 1e-9)
+
+	total, ok := aggregate.TotalPrecipitation(points)
+	require.True(t, ok)
+	require.InDelta(t, 0.5, total, 1e-9)
+
+	gust, ok := aggregate.MaxGust(points)
+	require.True(t, ok)
+	require.Equal(t, 4.0, gust)
+
+	require.Equal(t, 2*time.Hour, aggregate.DurationAbove(points, "temperature", 14))
+	require.Equal(t, 2.0, aggregate.HoursAbove(points, "temperature", 14))
+
+	// Two clear points (night counted as day) against two rain points: precipitation wins the tie
+	require.Equal(t, "rain", aggregate.DominantIcon(points))
+
+	_, ok = aggregate.Percentile(nil, "temperature", 50)
+	require.False(t, ok)
+}
@@ -0,0 +1,98 @@
+package aggregate
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/icons"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Min returns the smallest real value of the numeric field; ok is false if there are none
+func Min(points []models.DataPoint, name string) (float64, bool) {
+	return block(points).Min(name)
+}
+
+// Max returns the largest real value of the numeric field; ok is false if there are none
+func Max(points []models.DataPoint, name string) (float64, bool) {
+	return block(points).Max(name)
+}
+
+// Mean returns the mean of the real values of the numeric field; ok is false if there are none
+func Mean(points []models.DataPoint, name string) (float64, bool) {
+	return block(points).Mean(name)
+}
+
+// Sum returns the sum of the real values of the numeric field; ok is false if there are none
+func Sum(points []models.DataPoint, name string) (float64, bool) {
+	return block(points).Sum(name)
+}
+
+// Percentile returns the p-th percentile (0 to 100) of the real values of the numeric field,
+// interpolating between the closest ranks; ok is false if there are none
+func Percentile(points []models.DataPoint, name string, p float64) (float64, bool) {
+	values := block(points).Values(name)
+	if len(values) == 0 {
+		return 0, false
+	}
+	sort.Float64s(values)
+
+	rank := math.Max(0, math.Min(100, p)) / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(values)-1 {
+		return values[lower], true
+	}
+	return values[lower] + (values[lower+1]-values[lower])*(rank-float64(lower)), true
+}
+
+// TotalPrecipitation returns the precipitation accumulated over the points, in the response's
+// accumulation unit (cm or inches); ok is false if no point has an accumulation
+func TotalPrecipitation(points []models.DataPoint) (float64, bool) {
+	return Sum(points, "precipAccumulation")
+}
+
+// MaxGust returns the strongest wind gust; ok is false if no point has a gust
+func MaxGust(points []models.DataPoint) (float64, bool) {
+	return Max(points, "windGust")
+}
+
+// DurationAbove returns how long the numeric field stays above threshold. Each point lasts until
+// the next one, and the last point lasts as long as the one before it (an hour if it is alone).
+func DurationAbove(points []models.DataPoint, name string, threshold float64) time.Duration {
+	var total time.Duration
+	for i := range points {
+		value, ok := points[i].Value(name)
+		if ok && value > threshold {
+			total += span(points, i)
+		}
+	}
+	return total
+}
+
+// HoursAbove returns DurationAbove in hours
+func HoursAbove(points []models.DataPoint, name string, threshold float64) float64 {
+	return DurationAbove(points, name, threshold).Hours()
+}
+
+// DominantIcon returns the most frequent icon, counting night icons as their day variants
+// and preferring precipitation icons on ties (see icons.Dominant)
+func DominantIcon(points []models.DataPoint) string {
+	return icons.Dominant(points).String()
+}
+
+// span returns the duration of the i-th point: the gap to the next point, or for the last point the gap before it
+func span(points []models.DataPoint, i int) time.Duration {
+	switch {
+	case i+1 < len(points):
+		return time.Duration(points[i+1].Time-points[i].Time) * time.Second
+	case i > 0:
+		return time.Duration(points[i].Time-points[i-1].Time) * time.Second
+	default:
+		return time.Hour
+	}
+}
+
+func block(points []models.DataPoint) *models.DataBlock {
+	return &models.DataBlock{Data: points}
+}
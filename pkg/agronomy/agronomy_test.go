@@ -0,0 +1,131 @@
+package agronomy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/agronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// brussels is the day of FAO-56 example 18: 6 July at 50°48' N and 100 m, with 9.25 of 16.1 possible hours of sunshine
+func brussels() models.DataPoint {
+	p := models.DataPoint{
+		Time:           time.Date(2023, 7, 6, 0, 0, 0, 0, time.UTC).Unix(),
+		TemperatureMin: 12.3,
+		TemperatureMax: 21.5,
+		Humidity:       0.735,
+		WindSpeed:      10 / 3.6,
+		CloudCover:     1 - 9.25/16.1,
+	}
+	p.SetAbsent("dewPoint", "pressure")
+	return p
+}
+
+func TestReferenceET(t *testing.T) {
+	p := brussels()
+	estimate, err := agronomy.ReferenceET(&p, "si", 50.8, 100)
+	require.NoError(t, err)
+	require.Equal(t, agronomy.PenmanMonteith, estimate.Method)
+	require.InDelta(t, 3.9, estimate.ET0, 0.2)
+	require.InDelta(t, 22.1, estimate.Radiation, 0.5)
+
+	// The same day in US units with the sea level pressure
+	us := brussels()
+	us.TemperatureMin, us.TemperatureMax = 54.14, 70.7
+	us.WindSpeed = 10 / 1.609344
+	us.Pressure = 1013.25
+	converted, err := agronomy.ReferenceET(&us, "us", 50.8, 100)
+	require.NoError(t, err)
+	require.InDelta(t, estimate.ET0, converted.ET0, 0.01)
+
+	// Without wind, Hargreaves from the temperatures
+	p.SetAbsent("windSpeed")
+	estimate, err = agronomy.ReferenceET(&p, "si", 50.8, 100)
+	require.NoError(t, err)
+	require.Equal(t, agronomy.Hargreaves, estimate.Method)
+	require.Equal(t, "Hargreaves", estimate.Method.String())
+	require.InDelta(t, 3.9, estimate.ET0, 0.6)
+
+	p.SetAbsent("temperatureMax")
+	_, err = agronomy.ReferenceET(&p, "si", 50.8, 100)
+	require.ErrorIs(t, err, meteorology.ErrMissingInput)
+}
+
+func TestWaterBalance(t *testing.T) {
+	start := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+	var estimates []agronomy.Estimate
+	for i, rain := range []float64{0, 25, 0, 0, 0, 0} {
+		estimates = append(estimates, agronomy.Estimate{Time: start.AddDate(0, 0, i), ET0: 5, Precipitation: rain})
+	}
+
+	advice, err := agronomy.WaterBalance(estimates,
+		agronomy.WithAvailableWater(60),
+		agronomy.WithDepletion(20),
+		agronomy.WithCropCoefficient(1.2),
+		agronomy.WithEffectiveRainfall(1),
+	)
+	require.NoError(t, err)
+	require.Len(t, advice.Days, 6)
+	require.InDelta(t, 6, advice.Days[0].ETc, 1e-9)
+	require.InDelta(t, 26, advice.Days[0].Depletion, 1e-9)
+	require.InDelta(t, 7, advice.Days[1].Depletion, 1e-9)
+
+	// 7 + 6 × 4 = 31 mm exceeds the 30 mm readily available on the last day
+	require.Zero(t, advice.Days[4].Irrigation)
+	require.InDelta(t, 31, advice.Days[5].Irrigation, 1e-9)
+	require.Zero(t, advice.Days[5].Depletion)
+	require.Equal(t, start.AddDate(0, 0, 5), advice.Next)
+	require.InDelta(t, 31, advice.NextAmount, 1e-9)
+	require.InDelta(t, 31, advice.Irrigation, 1e-9)
+	require.Equal(t, "Irrigate 31 mm on Sat Jul 20.", advice.Summary())
+
+	// Rain beyond field capacity drains away
+	advice, err = agronomy.WaterBalance(estimates[:3], agronomy.WithEffectiveRainfall(1))
+	require.NoError(t, err)
+	require.InDelta(t, 5, advice.Days[0].Depletion, 1e-9)
+	require.Zero(t, advice.Days[1].Depletion)
+	require.True(t, advice.Next.IsZero())
+	require.Equal(t, "No irrigation needed through Wed Jul 17.", advice.Summary())
+
+	// A skipped day would silently drop its evapotranspiration
+	_, err = agronomy.WaterBalance(append(estimates[:2:2], estimates[3:]...))
+	require.ErrorIs(t, err, agronomy.ErrNotConsecutive)
+	_, err = agronomy.WaterBalance(append(estimates[:2:2], estimates[1:]...))
+	require.ErrorIs(t, err, agronomy.ErrNotConsecutive)
+}
+
+func TestAdvise(t *testing.T) {
+	day := brussels()
+	day.PrecipAccumulation = 0.1 // cm
+	forecast := &models.ForecastResponse{
+		Latitude:  50.8,
+		Elevation: 100,
+		Timezone:  "Europe/Brussels",
+		Flags:     &models.Flags{Units: "si"},
+		Daily:     &models.DataBlock{Data: []models.DataPoint{day}},
+	}
+	advice, err := agronomy.Advise(forecast, agronomy.WithDepletion(48))
+	require.NoError(t, err)
+	require.Len(t, advice.Days, 1)
+	require.InDelta(t, 0.8, advice.Days[0].Precipitation, 1e-9, "1 mm of which 80% is effective")
+	require.False(t, advice.Next.IsZero())
+	require.Equal(t, "Europe/Brussels", advice.Next.Location().String())
+
+	// A day without temperatures between others breaks the run of days
+	var days []models.DataPoint
+	for i := 0; i < 3; i++ {
+		p := brussels()
+		p.Time += int64(i) * 86400
+		days = append(days, p)
+	}
+	days[1].SetAbsent("temperatureMax")
+	forecast.Daily.Data = days
+	_, err = agronomy.Advise(forecast)
+	require.ErrorIs(t, err, agronomy.ErrNotConsecutive)
+
+	_, err = agronomy.Advise(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoDaily)
+}
@@ -0,0 +1,149 @@
+package agronomy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// ErrNotConsecutive is returned by WaterBalance when its estimates skip or repeat a day, as
+// ReferenceETs does for days without temperatures
+var ErrNotConsecutive = errors.New("estimates are not consecutive days")
+
+type config struct {
+	cropCoefficient   float64
+	availableWater    float64
+	depletionFraction float64
+	depletion         float64
+	effectiveRainfall float64
+}
+
+// Option configures a water balance
+type Option func(*config)
+
+// WithCropCoefficient sets the crop coefficient Kc that scales ET0 to the crop's evapotranspiration (1 by default)
+func WithCropCoefficient(kc float64) Option {
+	return func(c *config) {
+		c.cropCoefficient = kc
+	}
+}
+
+// WithAvailableWater sets the total available water of the root zone in mm, the water held between
+// field capacity and the wilting point (100 mm by default)
+func WithAvailableWater(mm float64) Option {
+	return func(c *config) {
+		c.availableWater = mm
+	}
+}
+
+// WithDepletionFraction sets the fraction of the available water the crop can use before it is
+// stressed and irrigation is due (0.5 by default)
+func WithDepletionFraction(p float64) Option {
+	return func(c *config) {
+		c.depletionFraction = p
+	}
+}
+
+// WithDepletion sets the root zone depletion in mm at the start of the first day (0, field capacity, by default)
+func WithDepletion(mm float64) Option {
+	return func(c *config) {
+		c.depletion = mm
+	}
+}
+
+// WithEffectiveRainfall sets the fraction of precipitation that reaches the root zone rather than
+// running off or being intercepted (0.8 by default)
+func WithEffectiveRainfall(fraction float64) Option {
+	return func(c *config) {
+		c.effectiveRainfall = fraction
+	}
+}
+
+// Balance is the root zone water balance of a day, in mm
+type Balance struct {
+	Time          time.Time
+	ET0           float64
+	ETc           float64 // crop evapotranspiration
+	Precipitation float64 // effective precipitation
+	Irrigation    float64 // recommended irrigation
+	Depletion     float64 // at the end of the day, after irrigation
+}
+
+// Advice is the water balance over a run of days with the irrigation that keeps the depletion
+// within the readily available water
+type Advice struct {
+	Days       []Balance
+	Irrigation float64   // total recommended irrigation, mm
+	Next       time.Time // first day irrigation is due, zero if none is
+	NextAmount float64   // mm to apply on Next
+}
+
+// WaterBalance runs a daily root zone water balance over the estimates, which must be consecutive days,
+// or it returns ErrNotConsecutive. When the depletion would exceed the readily available water, it
+// recommends irrigation back to field capacity.
+func WaterBalance(estimates []Estimate, opts ...Option) (Advice, error) {
+	c := config{cropCoefficient: 1, availableWater: 100, depletionFraction: 0.5, effectiveRainfall: 0.8}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	readily := c.depletionFraction * c.availableWater
+
+	var advice Advice
+	depletion := c.depletion
+	for i, e := range estimates {
+		if i > 0 && !sameDay(e.Time, estimates[i-1].Time.AddDate(0, 0, 1)) {
+			return Advice{}, fmt.Errorf("%w: %s follows %s", ErrNotConsecutive,
+				e.Time.Format(time.DateOnly), estimates[i-1].Time.Format(time.DateOnly))
+		}
+		day := Balance{
+			Time:          e.Time,
+			ET0:           e.ET0,
+			ETc:           e.ET0 * c.cropCoefficient,
+			Precipitation: e.Precipitation * c.effectiveRainfall,
+		}
+		// Water beyond field capacity drains below the root zone
+		depletion = min(max(depletion-day.Precipitation+day.ETc, 0), c.availableWater)
+		if depletion > readily {
+			day.Irrigation = depletion
+			depletion = 0
+			advice.Irrigation += day.Irrigation
+			if advice.Next.IsZero() {
+				advice.Next, advice.NextAmount = day.Time, day.Irrigation
+			}
+		}
+		day.Depletion = depletion
+		advice.Days = append(advice.Days, day)
+	}
+	return advice, nil
+}
+
+// sameDay reports whether b falls on the calendar day of a, in a's location
+func sameDay(a, b time.Time) bool {
+	y1, m1, d1 := a.Date()
+	y2, m2, d2 := b.In(a.Location()).Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// Advise computes ET0 for a forecast and runs the water balance over its days. It returns
+// ErrNotConsecutive when a day between others lacks the temperatures ET0 needs.
+func Advise(forecast *models.ForecastResponse, opts ...Option) (Advice, error) {
+	estimates, err := ReferenceETs(forecast)
+	if err != nil {
+		return Advice{}, err
+	}
+	return WaterBalance(estimates, opts...)
+}
+
+// Summary describes the next irrigation, e.g. "Irrigate 52 mm on Wed Jul 17."
+func (a Advice) Summary() string {
+	switch {
+	case len(a.Days) == 0:
+		return "No forecast days."
+	case a.Next.IsZero():
+		return fmt.Sprintf("No irrigation needed through %s.", a.Days[len(a.Days)-1].Time.Format("Mon Jan 2"))
+	default:
+		return fmt.Sprintf("Irrigate %.0f mm on %s.", a.NextAmount, a.Next.Format("Mon Jan 2"))
+	}
+}
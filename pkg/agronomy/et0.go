@@ -0,0 +1,178 @@
+// Package agronomy computes reference evapotranspiration (ET0) from daily points with the FAO-56
+// Penman-Monteith equation, falling back to Hargreaves when inputs are missing, and keeps a root
+// zone water balance from which it recommends irrigation. Water depths are in mm in every units system.
+package agronomy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/aggregate"
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Method is the equation an ET0 estimate was computed with
+type Method int
+
+const (
+	PenmanMonteith Method = iota
+	Hargreaves
+)
+
+func (m Method) String() string {
+	if m == Hargreaves {
+		return "Hargreaves"
+	}
+	return "Penman-Monteith"
+}
+
+// FAO-56 constants
+const (
+	stefanBoltzmann = 4.903e-9 // MJ/K⁴/m²/day
+	albedo          = 0.23     // of the grass reference crop
+	angstromA       = 0.25     // fraction of extraterrestrial radiation reaching the ground on overcast days
+	angstromB       = 0.50     // additional fraction on clear days
+)
+
+// Estimate is the reference evapotranspiration of a day
+type Estimate struct {
+	Time          time.Time
+	ET0           float64 // mm
+	Method        Method
+	Radiation     float64 // estimated solar radiation at the ground, MJ/m²
+	Precipitation float64 // mm, 0 when missing
+}
+
+// saturation returns the saturation vapour pressure in kPa at a temperature in °C
+func saturation(temperature float64) float64 {
+	return 0.6108 * math.Exp(17.27*temperature/(temperature+237.3))
+}
+
+// ReferenceET computes the ET0 of a daily point in the units system from its minimum and maximum
+// temperatures, at a latitude and an elevation in metres. Penman-Monteith needs the wind speed, the
+// cloud cover and either the dew point or the humidity; solar radiation is estimated from the cloud
+// cover and the sun's geometry. Without them, Hargreaves is used from the temperatures alone.
+func ReferenceET(p *models.DataPoint, units string, latitude, elevation float64) (Estimate, error) {
+	toSI := func(q models.Quantity, name string) (float64, bool, error) {
+		value, ok := p.Value(name)
+		if !ok {
+			return 0, false, nil
+		}
+		value, err := models.ConvertValue(q, value, units, models.UnitsSI)
+		return value, true, err
+	}
+	values := make(map[string]float64)
+	for name, q := range map[string]models.Quantity{
+		"temperatureMin":     models.QuantityTemperature,
+		"temperatureMax":     models.QuantityTemperature,
+		"dewPoint":           models.QuantityTemperature,
+		"windSpeed":          models.QuantitySpeed,
+		"precipAccumulation": models.QuantityPrecipAccumulation,
+		"humidity":           models.QuantityNone,
+		"cloudCover":         models.QuantityNone,
+		"pressure":           models.QuantityPressure,
+	} {
+		value, ok, err := toSI(q, name)
+		if err != nil {
+			return Estimate{}, err
+		}
+		if ok {
+			values[name] = value
+		}
+	}
+
+	tMin, okMin := values["temperatureMin"]
+	tMax, okMax := values["temperatureMax"]
+	if !okMin || !okMax {
+		return Estimate{}, fmt.Errorf("%w: temperatureMin and temperatureMax", meteorology.ErrMissingInput)
+	}
+	if tMin > tMax {
+		tMin, tMax = tMax, tMin
+	}
+	tMean := (tMin + tMax) / 2
+	date := p.LocalTime()
+	extraterrestrial := astronomy.ExtraterrestrialRadiation(date, latitude)
+	estimate := Estimate{Time: date, Precipitation: values["precipAccumulation"] * 10}
+
+	windSpeed, hasWind := values["windSpeed"]
+	cloudCover, hasCloudCover := values["cloudCover"]
+	dewPoint, hasDewPoint := values["dewPoint"]
+	humidity, hasHumidity := values["humidity"]
+	if !hasWind || !hasCloudCover || !hasDewPoint && !hasHumidity {
+		estimate.Method = Hargreaves
+		estimate.Radiation = 0.16 * math.Sqrt(tMax-tMin) * extraterrestrial
+		estimate.ET0 = math.Max(0.0023*(tMean+17.8)*math.Sqrt(tMax-tMin)*0.408*extraterrestrial, 0)
+		return estimate, nil
+	}
+
+	// Vapour pressures in kPa
+	saturated := (saturation(tMin) + saturation(tMax)) / 2
+	actual := humidity * saturated
+	if hasDewPoint {
+		actual = saturation(dewPoint)
+	}
+
+	// Net radiation in MJ/m², with clear sky radiation for the longwave cloudiness factor
+	solar := (angstromA + angstromB*(1-cloudCover)) * extraterrestrial
+	clearSky := (0.75 + 2e-5*elevation) * extraterrestrial
+	ratio := 1.0
+	if clearSky > 0 {
+		ratio = math.Min(solar/clearSky, 1)
+	}
+	kelvin := func(t float64) float64 { return math.Pow(t+273.16, 4) }
+	longwave := stefanBoltzmann * (kelvin(tMax) + kelvin(tMin)) / 2 * (0.34 - 0.14*math.Sqrt(actual)) * (1.35*ratio - 0.35)
+	net := (1-albedo)*solar - longwave
+
+	// Station pressure in kPa from the sea level pressure, or from the standard atmosphere
+	adjustment := math.Pow((293-0.0065*elevation)/293, 5.26)
+	pressure := 101.3 * adjustment
+	if seaLevel, ok := values["pressure"]; ok {
+		pressure = seaLevel / 10 * adjustment
+	}
+	psychrometric := 0.000665 * pressure
+	slope := 4098 * saturation(tMean) / math.Pow(tMean+237.3, 2)
+	wind := windSpeed * 4.87 / math.Log(67.8*10-5.42) // from 10 m to 2 m
+
+	estimate.Radiation = solar
+	estimate.ET0 = math.Max((0.408*slope*net+psychrometric*900/(tMean+273)*wind*(saturated-actual))/
+		(slope+psychrometric*(1+0.34*wind)), 0)
+	return estimate, nil
+}
+
+// ReferenceETs computes the ET0 of a forecast or TimeMachine response's daily block, or of its hourly
+// block rolled up into days when it has no daily block. Days without temperatures are skipped.
+func ReferenceETs(forecast *models.ForecastResponse) ([]Estimate, error) {
+	var days []models.DataPoint
+	if forecast.Daily != nil && len(forecast.Daily.Data) > 0 {
+		days = make([]models.DataPoint, len(forecast.Daily.Data))
+		copy(days, forecast.Daily.Data)
+		for i := range days {
+			days[i].SetLocation(forecast.Location())
+		}
+	} else {
+		daily, err := aggregate.Daily(forecast)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrNoDaily, err)
+		}
+		days = daily.Data
+	}
+
+	var estimates []Estimate
+	for i := range days {
+		estimate, err := ReferenceET(&days[i], forecast.Units(), forecast.Latitude, forecast.Elevation)
+		if errors.Is(err, meteorology.ErrMissingInput) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		estimates = append(estimates, estimate)
+	}
+	sort.SliceStable(estimates, func(i, j int) bool { return estimates[i].Time.Before(estimates[j].Time) })
+	return estimates, nil
+}
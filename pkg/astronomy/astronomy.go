@@ -0,0 +1,74 @@
+// Package astronomy computes the position of the sun and moon, twilight, rise and set times and
+// the phase of the moon offline for any location and date. Sun times are accurate to about a
+// minute and moon times to a few minutes away from the poles, which is ample for weather use.
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	julianUnixEpoch = 2440587.5
+	julianJ2000     = 2451545.0
+	synodicMonth    = 29.530588853
+	degrees         = math.Pi / 180
+	obliquity       = 23.4397 * degrees
+)
+
+// Position is the position of a body in the sky in degrees. Azimuth is measured clockwise from north.
+type Position struct {
+	Elevation float64
+	Azimuth   float64
+}
+
+// Interval is a span of time. Start or End is zero when the body does not cross the bounding elevation that day.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the interval, or 0 if either end is missing
+func (i Interval) Duration() time.Duration {
+	if i.Start.IsZero() || i.End.IsZero() {
+		return 0
+	}
+	return i.End.Sub(i.Start)
+}
+
+// Contains reports whether t is within the interval
+func (i Interval) Contains(t time.Time) bool {
+	return !i.Start.IsZero() && !i.End.IsZero() && !t.Before(i.Start) && t.Before(i.End)
+}
+
+func julianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + julianUnixEpoch
+}
+
+func fromJulianDay(jd float64) time.Time {
+	return time.Unix(int64(math.Round((jd-julianUnixEpoch)*86400)), 0)
+}
+
+// noon returns 12:00 on the calendar day of date in its location
+func noon(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 12, 0, 0, 0, date.Location())
+}
+
+// horizontal converts an hour angle and declination in radians to a position seen from the latitude
+func horizontal(hourAngle, declination, latitude float64) Position {
+	phi := latitude * degrees
+	sin := math.Sin(phi)*math.Sin(declination) + math.Cos(phi)*math.Cos(declination)*math.Cos(hourAngle)
+	azimuth := math.Atan2(math.Sin(hourAngle), math.Cos(hourAngle)*math.Sin(phi)-math.Tan(declination)*math.Cos(phi))
+	return Position{
+		Elevation: math.Asin(sin) / degrees,
+		Azimuth:   math.Mod(azimuth/degrees+540, 360),
+	}
+}
+
+// equatorial converts ecliptic longitude and latitude in radians to right ascension and declination
+func equatorial(longitude, latitude float64) (rightAscension, declination float64) {
+	rightAscension = math.Atan2(math.Sin(longitude)*math.Cos(obliquity)-math.Tan(latitude)*math.Sin(obliquity), math.Cos(longitude))
+	declination = math.Asin(math.Sin(latitude)*math.Cos(obliquity) + math.Cos(latitude)*math.Sin(obliquity)*math.Sin(longitude))
+	return rightAscension, declination
+}
@@ -0,0 +1,148 @@
+package astronomy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+const ottawaLat, ottawaLon = 45.42, -75.69
+
+func toronto(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	return loc
+}
+
+func requireNear(t *testing.T, want, got time.Time, within time.Duration) {
+	t.Helper()
+	diff := got.Sub(want)
+	require.True(t, diff > -within && diff < within, "want %v, got %v", want, got)
+}
+
+func TestSun(t *testing.T) {
+	loc := toronto(t)
+	solstice := time.Date(2024, 6, 21, 0, 0, 0, 0, loc)
+	sun := astronomy.Sun(solstice, ottawaLat, ottawaLon)
+
+	// NOAA solar calculator times for Ottawa on the 2024 June solstice
+	requireNear(t, time.Date(2024, 6, 21, 5, 14, 27, 0, loc), sun.Sunrise, time.Minute)
+	requireNear(t, time.Date(2024, 6, 21, 20, 54, 58, 0, loc), sun.Sunset, time.Minute)
+	requireNear(t, time.Date(2024, 6, 21, 13, 4, 43, 0, loc), sun.SolarNoon, time.Minute)
+	require.Equal(t, loc, sun.Sunrise.Location())
+
+	require.True(t, sun.AstronomicalDawn.Before(sun.NauticalDawn))
+	require.True(t, sun.NauticalDawn.Before(sun.CivilDawn))
+	require.True(t, sun.CivilDawn.Before(sun.Sunrise))
+	require.True(t, sun.CivilDusk.After(sun.Sunset))
+	require.InDelta(t, (15*time.Hour + 40*time.Minute).Hours(), sun.DayLength.Hours(), 0.1)
+
+	// Blue hour leads into golden hour, which spans sunrise
+	require.Equal(t, sun.MorningBlueHour.End, sun.MorningGoldenHour.Start)
+	require.True(t, sun.MorningGoldenHour.Contains(sun.Sunrise))
+	require.True(t, sun.EveningGoldenHour.Contains(sun.Sunset))
+	require.InDelta(t, 20, sun.MorningBlueHour.Duration().Minutes(), 10)
+}
+
+func TestPolarDayAndNight(t *testing.T) {
+	const tromsoLat, tromsoLon = 69.65, 18.96
+
+	midsummer := astronomy.Sun(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), tromsoLat, tromsoLon)
+	require.True(t, midsummer.Sunrise.IsZero())
+	require.Equal(t, 24*time.Hour, midsummer.DayLength)
+
+	midwinter := astronomy.Sun(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), tromsoLat, tromsoLon)
+	require.True(t, midwinter.Sunrise.IsZero())
+	require.Zero(t, midwinter.DayLength)
+	require.False(t, midwinter.CivilDawn.IsZero())
+	require.Zero(t, midwinter.MorningGoldenHour.Duration())
+}
+
+func TestSunPositionAndTwilight(t *testing.T) {
+	loc := toronto(t)
+	noon := astronomy.SolarNoon(time.Date(2024, 6, 21, 0, 0, 0, 0, loc), ottawaLon)
+	position := astronomy.SunPosition(noon, ottawaLat, ottawaLon)
+	require.InDelta(t, 90-ottawaLat+23.44, position.Elevation, 0.2)
+	require.InDelta(t, 180, position.Azimuth, 1)
+
+	morning := astronomy.SunPosition(time.Date(2024, 6, 21, 8, 0, 0, 0, loc), ottawaLat, ottawaLon)
+	require.Greater(t, morning.Azimuth, 45.0)
+	require.Less(t, morning.Azimuth, 135.0)
+
+	require.Equal(t, astronomy.Daylight, astronomy.Twilight(noon, ottawaLat, ottawaLon))
+	require.Equal(t, astronomy.Night, astronomy.Twilight(noon.Add(12*time.Hour), ottawaLat, ottawaLon))
+	sun := astronomy.Sun(noon, ottawaLat, ottawaLon)
+	require.Equal(t, astronomy.CivilTwilight, astronomy.Twilight(sun.Sunset.Add(10*time.Minute), ottawaLat, ottawaLon))
+	require.Equal(t, "nautical twilight", astronomy.Twilight(sun.CivilDusk.Add(10*time.Minute), ottawaLat, ottawaLon).String())
+}
+
+func TestExtraterrestrialRadiation(t *testing.T) {
+	// FAO-56 example 8: 3 September at 20° S, within the error of the example's approximate declination
+	require.InDelta(t, 32.2, astronomy.ExtraterrestrialRadiation(time.Date(2023, 9, 3, 0, 0, 0, 0, time.UTC), -20), 0.5)
+	require.Zero(t, astronomy.ExtraterrestrialRadiation(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), 80), "polar night")
+	require.Greater(t, astronomy.ExtraterrestrialRadiation(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), 80), 40.0)
+}
+
+func TestMoon(t *testing.T) {
+	full := astronomy.MoonIllumination(time.Date(2024, 6, 22, 1, 8, 0, 0, time.UTC))
+	require.InDelta(t, 1, full.Fraction, 0.01)
+	require.InDelta(t, 0.5, full.Phase, 0.002)
+	require.Equal(t, "full moon", full.Name)
+
+	firstQuarter := time.Date(2024, 6, 14, 5, 18, 0, 0, time.UTC)
+	quarter := astronomy.MoonIllumination(firstQuarter)
+	require.InDelta(t, 0.5, quarter.Fraction, 0.03)
+	require.Equal(t, "first quarter", quarter.Name)
+	require.InDelta(t, 7.4, astronomy.MoonAge(firstQuarter), 0.5)
+
+	require.Equal(t, "waning crescent", astronomy.PhaseName(0.9))
+	require.Equal(t, "new moon", astronomy.PhaseName(0.97))
+	require.Equal(t, "new moon", astronomy.PhaseName(-0.01))
+
+	loc := toronto(t)
+	moon := astronomy.Moon(time.Date(2024, 6, 21, 0, 0, 0, 0, loc), ottawaLat, ottawaLon)
+	require.False(t, moon.Rise.IsZero())
+	require.Equal(t, 21, moon.Rise.Day())
+	require.InDelta(t, 0.133, astronomy.MoonPosition(moon.Rise, ottawaLat, ottawaLon).Elevation, 0.01)
+	require.Less(t, astronomy.MoonPosition(moon.Rise.Add(-10*time.Minute), ottawaLat, ottawaLon).Elevation, 0.133)
+	if !moon.Set.IsZero() {
+		require.InDelta(t, 0.133, astronomy.MoonPosition(moon.Set, ottawaLat, ottawaLon).Elevation, 0.01)
+	}
+}
+
+func TestFillAndCheckDaily(t *testing.T) {
+	forecast := synthetic.Generate(ottawaLat, ottawaLon, time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC),
+		synthetic.WithTimezone("America/Toronto"), synthetic.WithVersion(2))
+	reference := forecast.Clone()
+
+	discrepancies, err := astronomy.CheckDaily(forecast, astronomy.DefaultTolerance)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	for i := range forecast.Daily.Data {
+		forecast.Daily.Data[i].SetAbsent("dawnTime", "duskTime", "moonPhase")
+	}
+	forecast.Daily.Data[0].SunriseTime = 0
+	require.NoError(t, astronomy.FillDaily(forecast))
+	for i, day := range forecast.Daily.Data {
+		want := reference.Daily.Data[i]
+		require.Equal(t, want.SunriseTime, day.SunriseTime)
+		require.Equal(t, want.DawnTime, day.DawnTime)
+		require.Equal(t, want.DuskTime, day.DuskTime)
+		require.InDelta(t, want.MoonPhase, day.MoonPhase, 0.01) // synthetic phases are rounded
+		require.False(t, day.IsMissing("moonPhase"))
+	}
+
+	forecast.Daily.Data[2].SunsetTime += 3600
+	discrepancies, err = astronomy.CheckDaily(forecast, astronomy.DefaultTolerance)
+	require.NoError(t, err)
+	require.Len(t, discrepancies, 1)
+	require.Equal(t, "sunsetTime", discrepancies[0].Field)
+	require.Equal(t, 3600.0, discrepancies[0].Reported-discrepancies[0].Computed)
+
+	require.ErrorIs(t, astronomy.FillDaily(&models.ForecastResponse{}), models.ErrNoDaily)
+}
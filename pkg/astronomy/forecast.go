@@ -0,0 +1,110 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// dailyEvents are the daily time fields computed from the sun, with the elevation they mark and
+// whether the field is the rising or setting crossing
+var dailyEvents = []struct {
+	field     string
+	elevation float64
+	rising    bool
+}{
+	{"sunriseTime", SunriseElevation, true},
+	{"sunsetTime", SunriseElevation, false},
+	{"dawnTime", CivilTwilightElevation, true},
+	{"duskTime", CivilTwilightElevation, false},
+}
+
+// computeDaily returns the computed value of each daily field for the day starting at date.
+// Events the sun does not reach that day are left out.
+func computeDaily(date time.Time, latitude, longitude float64) map[string]float64 {
+	values := map[string]float64{"moonPhase": MoonIllumination(noon(date)).Phase}
+	for _, event := range dailyEvents {
+		rise, set, ok := SunCrossing(date, latitude, longitude, event.elevation)
+		if !ok {
+			continue
+		}
+		if event.rising {
+			values[event.field] = float64(rise.Unix())
+		} else {
+			values[event.field] = float64(set.Unix())
+		}
+	}
+	return values
+}
+
+// FillDaily computes the sunrise, sunset, dawn, dusk and moon phase of each daily point where the
+// API left them out, e.g. dawn and dusk on version 1 responses. Time fields holding 0 also count as missing.
+func FillDaily(forecast *models.ForecastResponse) error {
+	if forecast.Daily == nil {
+		return models.ErrNoDaily
+	}
+	loc := forecast.Location()
+	for i := range forecast.Daily.Data {
+		p := &forecast.Daily.Data[i]
+		date := time.Unix(p.Time, 0).In(loc)
+		for field, value := range computeDaily(date, forecast.Latitude, forecast.Longitude) {
+			if current, ok := p.Value(field); !ok || (current == 0 && field != "moonPhase") {
+				p.SetValue(field, value)
+			}
+		}
+	}
+	return nil
+}
+
+// Tolerance is how far a reported daily value may be from the computed one
+type Tolerance struct {
+	Time      time.Duration
+	MoonPhase float64
+}
+
+// DefaultTolerance allows for differences in the algorithms and in the time of day the moon phase is taken
+var DefaultTolerance = Tolerance{Time: 5 * time.Minute, MoonPhase: 0.03}
+
+// Discrepancy is a daily value that differs from the computed one by more than the tolerance.
+// Time fields are Unix seconds.
+type Discrepancy struct {
+	Day      time.Time
+	Field    string
+	Reported float64
+	Computed float64
+}
+
+// CheckDaily compares the reported sunrise, sunset, dawn, dusk and moon phase of each daily point with
+// the computed values and returns those outside the tolerance. Missing fields are not checked.
+func CheckDaily(forecast *models.ForecastResponse, tolerance Tolerance) ([]Discrepancy, error) {
+	if forecast.Daily == nil {
+		return nil, models.ErrNoDaily
+	}
+	fields := []string{"sunriseTime", "sunsetTime", "dawnTime", "duskTime", "moonPhase"}
+	loc := forecast.Location()
+
+	var discrepancies []Discrepancy
+	for i := range forecast.Daily.Data {
+		p := &forecast.Daily.Data[i]
+		date := time.Unix(p.Time, 0).In(loc)
+		computed := computeDaily(date, forecast.Latitude, forecast.Longitude)
+		for _, field := range fields {
+			reported, ok := p.Value(field)
+			expected, known := computed[field]
+			if !ok || !known || (reported == 0 && field != "moonPhase") {
+				continue
+			}
+			var outside bool
+			if field == "moonPhase" {
+				outside = math.Abs(math.Remainder(reported-expected, 1)) > tolerance.MoonPhase
+			} else {
+				outside = math.Abs(reported-expected) > tolerance.Time.Seconds()
+			}
+			if outside {
+				discrepancies = append(discrepancies, Discrepancy{Day: date, Field: field, Reported: reported, Computed: expected})
+			}
+		}
+	}
+	return discrepancies, nil
+}
@@ -0,0 +1,168 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+// moonriseElevation is the moon's elevation at moonrise and moonset once refraction is included
+const moonriseElevation = 0.133
+
+// moonEcliptic returns the moon's ecliptic longitude and latitude in radians and its distance in km,
+// d days after J2000, using the largest periodic terms of the lunar theory
+func moonEcliptic(d float64) (longitude, latitude, distance float64) {
+	meanLongitude := (218.316 + 13.176396*d) * degrees
+	anomaly := (134.963 + 13.064993*d) * degrees
+	argument := (93.272 + 13.229350*d) * degrees
+	elongation := (297.850 + 12.190749*d) * degrees
+	sunAnomaly := (357.529 + 0.98560028*d) * degrees
+
+	longitude = meanLongitude + degrees*(6.289*math.Sin(anomaly)+
+		1.274*math.Sin(2*elongation-anomaly)+
+		0.658*math.Sin(2*elongation)+
+		0.214*math.Sin(2*anomaly)-
+		0.186*math.Sin(sunAnomaly)-
+		0.114*math.Sin(2*argument))
+	latitude = degrees * (5.128*math.Sin(argument) +
+		0.281*math.Sin(anomaly+argument) +
+		0.278*math.Sin(anomaly-argument) +
+		0.173*math.Sin(2*elongation-argument))
+	distance = 385001 - 20905*math.Cos(anomaly) - 3699*math.Cos(2*elongation-anomaly) -
+		2956*math.Cos(2*elongation) - 570*math.Cos(2*anomaly)
+	return longitude, latitude, distance
+}
+
+// moonCoordinates returns the moon's right ascension and declination in radians and its distance in km, d days after J2000
+func moonCoordinates(d float64) (rightAscension, declination, distance float64) {
+	longitude, latitude, distance := moonEcliptic(d)
+	rightAscension, declination = equatorial(longitude, latitude)
+	return rightAscension, declination, distance
+}
+
+// MoonPosition returns the position of the moon at t, including atmospheric refraction
+func MoonPosition(t time.Time, latitude, longitude float64) Position {
+	d := julianDay(t) - julianJ2000
+	rightAscension, declination, _ := moonCoordinates(d)
+	siderealTime := (280.16+360.9856235*d)*degrees + longitude*degrees
+	position := horizontal(siderealTime-rightAscension, declination, latitude)
+	position.Elevation += refraction(position.Elevation)
+	return position
+}
+
+// refraction returns the atmospheric refraction in degrees at an elevation in degrees
+func refraction(elevation float64) float64 {
+	h := math.Max(elevation, 0) * degrees
+	return 0.0002967 / math.Tan(h+0.00312536/(h+0.08901179)) / degrees
+}
+
+// MoonTimes are the moonrise and moonset of a day. The moon rises about 50 minutes later each day,
+// so some days have no moonrise or no moonset; those times are zero.
+type MoonTimes struct {
+	Rise       time.Time
+	Set        time.Time
+	AlwaysUp   bool
+	AlwaysDown bool
+}
+
+// moonStep is the sampling interval when searching for moonrise and moonset
+const moonStep = 10 * time.Minute
+
+// Moon returns the moonrise and moonset on the calendar day of date, in date's location
+func Moon(date time.Time, latitude, longitude float64) MoonTimes {
+	year, month, day := date.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+	above := func(t time.Time) bool {
+		return MoonPosition(t, latitude, longitude).Elevation > moonriseElevation
+	}
+
+	var times MoonTimes
+	wasAbove := above(start)
+	for t := start; t.Before(end); t = t.Add(moonStep) {
+		next := t.Add(moonStep)
+		if next.After(end) {
+			next = end
+		}
+		isAbove := above(next)
+		if isAbove != wasAbove {
+			crossing := bisect(t, next, above, isAbove)
+			if isAbove && times.Rise.IsZero() {
+				times.Rise = crossing
+			} else if !isAbove && times.Set.IsZero() {
+				times.Set = crossing
+			}
+		}
+		wasAbove = isAbove
+	}
+	if times.Rise.IsZero() && times.Set.IsZero() {
+		times.AlwaysUp = wasAbove
+		times.AlwaysDown = !wasAbove
+	}
+	return times
+}
+
+// bisect narrows a crossing between from and to, where above(to) is toAbove, to the second
+func bisect(from, to time.Time, above func(time.Time) bool, toAbove bool) time.Time {
+	for to.Sub(from) > time.Second {
+		mid := from.Add(to.Sub(from) / 2)
+		if above(mid) == toAbove {
+			to = mid
+		} else {
+			from = mid
+		}
+	}
+	return to.Truncate(time.Second)
+}
+
+// Illumination describes the phase of the moon
+type Illumination struct {
+	// Fraction is the illuminated fraction of the disc, from 0 to 1
+	Fraction float64
+	// Phase is the lunation fraction like the API's moonPhase: 0 is a new moon, 0.25 the first quarter,
+	// 0.5 a full moon and 0.75 the last quarter
+	Phase float64
+	// Name is the phase name, such as "waxing gibbous"
+	Name string
+}
+
+// MoonIllumination returns the phase of the moon at t
+func MoonIllumination(t time.Time) Illumination {
+	const sunDistance = 149598000 // km
+	d := julianDay(t) - julianJ2000
+	sunLongitude := sunEclipticLongitude(d)
+	moonLongitude, moonLatitude, moonDistance := moonEcliptic(d)
+
+	// The phase follows the difference in ecliptic longitude; the lit fraction follows the
+	// angle between the sun and moon, which includes the moon's latitude
+	phase := math.Mod((moonLongitude-sunLongitude)/(2*math.Pi), 1)
+	if phase < 0 {
+		phase++
+	}
+	elongation := math.Acos(math.Cos(moonLatitude) * math.Cos(moonLongitude-sunLongitude))
+	phaseAngle := math.Atan2(sunDistance*math.Sin(elongation), moonDistance-sunDistance*math.Cos(elongation))
+	return Illumination{
+		Fraction: (1 + math.Cos(phaseAngle)) / 2,
+		Phase:    phase,
+		Name:     PhaseName(phase),
+	}
+}
+
+var phaseNames = []string{
+	"new moon", "waxing crescent", "first quarter", "waxing gibbous",
+	"full moon", "waning gibbous", "last quarter", "waning crescent",
+}
+
+// PhaseName names a lunation fraction. Each of the eight names covers an eighth of the
+// lunation centred on its phase, so "full moon" spans 0.4375 to 0.5625.
+func PhaseName(phase float64) string {
+	phase = math.Mod(phase, 1)
+	if phase < 0 {
+		phase++
+	}
+	return phaseNames[int(math.Floor(phase*8+0.5))%8]
+}
+
+// MoonAge returns the days since the last new moon at t
+func MoonAge(t time.Time) float64 {
+	return MoonIllumination(t).Phase * synodicMonth
+}
@@ -0,0 +1,173 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+// Solar elevations in degrees that bound sunrise, twilight and the golden and blue hours
+const (
+	// SunriseElevation accounts for refraction and the solar disc at sunrise and sunset
+	SunriseElevation              = -0.833
+	CivilTwilightElevation        = -6.0
+	NauticalTwilightElevation     = -12.0
+	AstronomicalTwilightElevation = -18.0
+	// GoldenHourElevation and BlueHourElevation bound the golden hour, from -4° to 6°,
+	// and the blue hour, from -6° to -4°
+	GoldenHourElevation = 6.0
+	BlueHourElevation   = -4.0
+)
+
+// solarCoordinates returns the solar transit nearest to jd (as a Julian day) and the declination at that transit
+func solarCoordinates(jd, longitude float64) (transit, declination float64) {
+	n := math.Round(jd - julianJ2000 - 0.0008 + longitude/360)
+	meanNoon := n - longitude/360
+	anomaly := math.Mod(357.5291+0.98560028*meanNoon, 360) * degrees
+	center := 1.9148*math.Sin(anomaly) + 0.02*math.Sin(2*anomaly) + 0.0003*math.Sin(3*anomaly)
+	ecliptic := math.Mod(anomaly/degrees+center+180+102.9372, 360) * degrees
+	transit = julianJ2000 + meanNoon + 0.0053*math.Sin(anomaly) - 0.0069*math.Sin(2*ecliptic)
+	declination = math.Asin(math.Sin(ecliptic) * math.Sin(obliquity))
+	return transit, declination
+}
+
+// sunEclipticLongitude returns the sun's ecliptic longitude in radians, d days after J2000
+func sunEclipticLongitude(d float64) float64 {
+	anomaly := (357.5291 + 0.98560028*d) * degrees
+	center := (1.9148*math.Sin(anomaly) + 0.02*math.Sin(2*anomaly) + 0.0003*math.Sin(3*anomaly)) * degrees
+	return anomaly + center + 102.9372*degrees + math.Pi
+}
+
+// SunPosition returns the position of the sun at t, without refraction
+func SunPosition(t time.Time, latitude, longitude float64) Position {
+	jd := julianDay(t)
+	transit, declination := solarCoordinates(jd, longitude)
+	return horizontal(2*math.Pi*(jd-transit), declination, latitude)
+}
+
+// SunCrossing returns the times the sun rises through and sets through the elevation on the
+// calendar day of date in its location. ok is false when the sun stays above or below it all day.
+func SunCrossing(date time.Time, latitude, longitude, elevation float64) (rise, set time.Time, ok bool) {
+	transit, declination := solarCoordinates(julianDay(noon(date)), longitude)
+	phi := latitude * degrees
+	cos := (math.Sin(elevation*degrees) - math.Sin(phi)*math.Sin(declination)) / (math.Cos(phi) * math.Cos(declination))
+	if cos < -1 || cos > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	hourAngle := math.Acos(cos) / degrees
+	loc := date.Location()
+	return fromJulianDay(transit - hourAngle/360).In(loc), fromJulianDay(transit + hourAngle/360).In(loc), true
+}
+
+// SolarNoon returns the time the sun is highest on the calendar day of date in its location
+func SolarNoon(date time.Time, longitude float64) time.Time {
+	transit, _ := solarCoordinates(julianDay(noon(date)), longitude)
+	return fromJulianDay(transit).In(date.Location())
+}
+
+// SolarConstant is the mean solar irradiance outside the atmosphere in W/m²
+const SolarConstant = 1367.0
+
+// ExtraterrestrialRadiation returns the solar radiation reaching the top of the atmosphere at the
+// latitude over the calendar day of date, in MJ/m² (FAO-56 equation 21)
+func ExtraterrestrialRadiation(date time.Time, latitude float64) float64 {
+	_, declination := solarCoordinates(julianDay(noon(date)), 0)
+	phi := latitude * degrees
+	sunset := math.Acos(math.Max(-1, math.Min(1, -math.Tan(phi)*math.Tan(declination))))
+	distance := 1 + 0.033*math.Cos(2*math.Pi*float64(date.YearDay())/365)
+	return 86400 / math.Pi * SolarConstant / 1e6 * distance *
+		(sunset*math.Sin(phi)*math.Sin(declination) + math.Cos(phi)*math.Cos(declination)*math.Sin(sunset))
+}
+
+// SunTimes are the sun events of a day. Times the sun does not reach that day, such as
+// sunrise during the polar night, are zero.
+type SunTimes struct {
+	SolarNoon        time.Time
+	Sunrise          time.Time
+	Sunset           time.Time
+	CivilDawn        time.Time
+	CivilDusk        time.Time
+	NauticalDawn     time.Time
+	NauticalDusk     time.Time
+	AstronomicalDawn time.Time
+	AstronomicalDusk time.Time
+
+	MorningBlueHour   Interval
+	MorningGoldenHour Interval
+	EveningGoldenHour Interval
+	EveningBlueHour   Interval
+
+	// DayLength is the time from sunrise to sunset: 24 hours during the midnight sun and 0 during the polar night
+	DayLength time.Duration
+}
+
+// Sun returns the sun events on the calendar day of date, in date's location
+func Sun(date time.Time, latitude, longitude float64) SunTimes {
+	crossing := func(elevation float64) (time.Time, time.Time) {
+		rise, set, _ := SunCrossing(date, latitude, longitude, elevation)
+		return rise, set
+	}
+
+	times := SunTimes{SolarNoon: SolarNoon(date, longitude)}
+	times.Sunrise, times.Sunset = crossing(SunriseElevation)
+	times.CivilDawn, times.CivilDusk = crossing(CivilTwilightElevation)
+	times.NauticalDawn, times.NauticalDusk = crossing(NauticalTwilightElevation)
+	times.AstronomicalDawn, times.AstronomicalDusk = crossing(AstronomicalTwilightElevation)
+
+	blueStart, blueEnd := crossing(BlueHourElevation)
+	goldenStart, goldenEnd := crossing(GoldenHourElevation)
+	times.MorningBlueHour = Interval{times.CivilDawn, blueStart}
+	times.MorningGoldenHour = Interval{blueStart, goldenStart}
+	times.EveningGoldenHour = Interval{goldenEnd, blueEnd}
+	times.EveningBlueHour = Interval{blueEnd, times.CivilDusk}
+
+	switch {
+	case !times.Sunrise.IsZero():
+		times.DayLength = times.Sunset.Sub(times.Sunrise)
+	case SunPosition(times.SolarNoon, latitude, longitude).Elevation > SunriseElevation:
+		times.DayLength = 24 * time.Hour
+	}
+	return times
+}
+
+// TwilightPhase is how dark the sky is, from night to daylight
+type TwilightPhase int
+
+const (
+	Night TwilightPhase = iota
+	AstronomicalTwilight
+	NauticalTwilight
+	CivilTwilight
+	Daylight
+)
+
+func (p TwilightPhase) String() string {
+	switch p {
+	case AstronomicalTwilight:
+		return "astronomical twilight"
+	case NauticalTwilight:
+		return "nautical twilight"
+	case CivilTwilight:
+		return "civil twilight"
+	case Daylight:
+		return "daylight"
+	default:
+		return "night"
+	}
+}
+
+// Twilight returns the twilight phase at t
+func Twilight(t time.Time, latitude, longitude float64) TwilightPhase {
+	elevation := SunPosition(t, latitude, longitude).Elevation
+	switch {
+	case elevation >= SunriseElevation:
+		return Daylight
+	case elevation >= CivilTwilightElevation:
+		return CivilTwilight
+	case elevation >= NauticalTwilightElevation:
+		return NauticalTwilight
+	case elevation >= AstronomicalTwilightElevation:
+		return AstronomicalTwilight
+	default:
+		return Night
+	}
+}
@@ -0,0 +1,293 @@
+// Package degreedays computes heating, cooling and growing degree days from the daily or hourly
+// points of forecasts and TimeMachine history, and combines the two into season totals.
+package degreedays
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/aggregate"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Kind is the kind of degree days
+type Kind int
+
+const (
+	Heating Kind = iota // degrees below the base
+	Cooling             // degrees above the base
+	Growing             // degrees above the base, up to the upper cutoff
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Cooling:
+		return "cooling degree days"
+	case Growing:
+		return "growing degree days"
+	default:
+		return "heating degree days"
+	}
+}
+
+// Method is how degree days are computed from a day's minimum and maximum temperatures
+type Method int
+
+const (
+	// Average takes the difference between the mean of the minimum and maximum and the base.
+	// It ignores the upper cutoff.
+	Average Method = iota
+	// Modified limits the minimum and maximum to the range from the base to the upper cutoff before averaging
+	Modified
+	// SingleSine fits a sine curve through the minimum and maximum and integrates it between the base
+	// and the upper cutoff, so days crossing the base still count some degrees
+	SingleSine
+)
+
+func (m Method) String() string {
+	switch m {
+	case Modified:
+		return "modified"
+	case SingleSine:
+		return "single sine"
+	default:
+		return "average"
+	}
+}
+
+type config struct {
+	base   float64
+	cutoff float64
+	method Method
+}
+
+// Option configures a Calculator
+type Option func(*config)
+
+// WithBase sets the base temperature in °C (18 °C for heating and cooling, 10 °C for growing by default)
+func WithBase(celsius float64) Option {
+	return func(c *config) {
+		c.base = celsius
+	}
+}
+
+// WithUpperCutoff sets the temperature in °C above which degrees do not count (30 °C for growing by default).
+// Heating degree days have no upper cutoff.
+func WithUpperCutoff(celsius float64) Option {
+	return func(c *config) {
+		c.cutoff = celsius
+	}
+}
+
+// WithMethod sets the method (Average by default)
+func WithMethod(m Method) Option {
+	return func(c *config) {
+		c.method = m
+	}
+}
+
+// Calculator computes one kind of degree days
+type Calculator struct {
+	kind   Kind
+	config config
+}
+
+// New creates a Calculator
+func New(kind Kind, opts ...Option) Calculator {
+	c := config{base: 18, cutoff: math.Inf(1)}
+	if kind == Growing {
+		c.base, c.cutoff = 10, 30
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return Calculator{kind: kind, config: c}
+}
+
+// Kind returns the kind of degree days the calculator computes
+func (c Calculator) Kind() Kind {
+	return c.kind
+}
+
+// Compute returns the degree days of a day with a minimum and maximum temperature in °C
+func (c Calculator) Compute(min, max float64) float64 {
+	if min > max {
+		min, max = max, min
+	}
+	base, cutoff := c.config.base, c.config.cutoff
+	if c.kind == Heating {
+		// Degrees below the base are degrees above it with the temperatures negated
+		min, max, base, cutoff = -max, -min, -base, math.Inf(1)
+	}
+
+	switch c.config.method {
+	case Modified:
+		clamp := func(t float64) float64 { return math.Max(base, math.Min(t, cutoff)) }
+		return (clamp(min)+clamp(max))/2 - base
+	case SingleSine:
+		return math.Max(above(min, max, base)-above(min, max, cutoff), 0)
+	default:
+		return math.Max((min+max)/2-base, 0)
+	}
+}
+
+// above integrates the degrees above threshold over a day whose temperature follows a sine curve from min to max
+func above(min, max, threshold float64) float64 {
+	mean, amplitude := (min+max)/2, (max-min)/2
+	switch {
+	case math.IsInf(threshold, 1) || threshold >= max:
+		return 0
+	case threshold <= min:
+		return mean - threshold
+	default:
+		theta := math.Asin((threshold - mean) / amplitude)
+		return ((mean-threshold)*(math.Pi/2-theta) + amplitude*math.Cos(theta)) / math.Pi
+	}
+}
+
+// Day is the degree days of a day
+type Day struct {
+	Time       time.Time // start of the day
+	Min        float64   // minimum temperature
+	Max        float64   // maximum temperature
+	DegreeDays float64   // in degrees of the units system
+	Projected  bool      // from a forecast rather than history; set by Season
+}
+
+// Days computes the degree days of daily points from their minimum and maximum temperatures, in
+// the points' units system. Days where either temperature is missing are skipped.
+func (c Calculator) Days(points []models.DataPoint, units string) ([]Day, error) {
+	scale, err := degreeScale(units)
+	if err != nil {
+		return nil, err
+	}
+	var days []Day
+	for i := range points {
+		p := &points[i]
+		min, okMin := p.Value("temperatureMin")
+		max, okMax := p.Value("temperatureMax")
+		if !okMin || !okMax {
+			continue
+		}
+		minSI, err := models.ConvertValue(models.QuantityTemperature, min, units, models.UnitsSI)
+		if err != nil {
+			return nil, err
+		}
+		maxSI, err := models.ConvertValue(models.QuantityTemperature, max, units, models.UnitsSI)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, Day{
+			Time:       p.LocalTime(),
+			Min:        min,
+			Max:        max,
+			DegreeDays: c.Compute(minSI, maxSI) * scale,
+		})
+	}
+	sort.SliceStable(days, func(i, j int) bool { return days[i].Time.Before(days[j].Time) })
+	return days, nil
+}
+
+// degreeScale returns the size of a degree of the units system in °C
+func degreeScale(units string) (float64, error) {
+	zero, err := models.ConvertValue(models.QuantityTemperature, 0, models.UnitsSI, units)
+	if err != nil {
+		return 0, err
+	}
+	one, err := models.ConvertValue(models.QuantityTemperature, 1, models.UnitsSI, units)
+	if err != nil {
+		return 0, err
+	}
+	return one - zero, nil
+}
+
+// Forecast computes the degree days of a forecast or TimeMachine response from its daily block, or
+// from its hourly block rolled up into days when it has no daily block. Times are in the forecast's timezone.
+func (c Calculator) Forecast(forecast *models.ForecastResponse) ([]Day, error) {
+	var days []models.DataPoint
+	if forecast.Daily != nil && len(forecast.Daily.Data) > 0 {
+		days = make([]models.DataPoint, len(forecast.Daily.Data))
+		copy(days, forecast.Daily.Data)
+		for i := range days {
+			days[i].SetLocation(forecast.Location())
+		}
+	} else {
+		daily, err := aggregate.Daily(forecast)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrNoDaily, err)
+		}
+		days = daily.Data
+	}
+	return c.Days(days, forecast.Units())
+}
+
+// Season is the degree days of past days from history followed by projected days from a forecast
+type Season struct {
+	Days      []Day
+	ToDate    float64 // total of the past days
+	Projected float64 // total of the forecast days
+}
+
+// Total returns the season-to-date total plus the projected total
+func (s Season) Total() float64 {
+	return s.ToDate + s.Projected
+}
+
+// Season combines the past days of TimeMachine responses with the future days of a forecast, in the
+// forecast's units system and timezone. A day covered by both is taken from history.
+func (c Calculator) Season(forecast *models.ForecastResponse, history ...*models.ForecastResponse) (Season, error) {
+	loc := forecast.Location()
+	scale, err := degreeScale(forecast.Units())
+	if err != nil {
+		return Season{}, err
+	}
+
+	seen := make(map[string]bool)
+	var season Season
+	add := func(response *models.ForecastResponse, projected bool) error {
+		days, err := c.Forecast(response)
+		if err != nil {
+			return err
+		}
+		from, err := degreeScale(response.Units())
+		if err != nil {
+			return err
+		}
+		for _, day := range days {
+			key := day.Time.In(loc).Format(time.DateOnly)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			day.Time = day.Time.In(loc)
+			if day.Min, err = models.ConvertValue(models.QuantityTemperature, day.Min, response.Units(), forecast.Units()); err != nil {
+				return err
+			}
+			if day.Max, err = models.ConvertValue(models.QuantityTemperature, day.Max, response.Units(), forecast.Units()); err != nil {
+				return err
+			}
+			day.DegreeDays *= scale / from
+			day.Projected = projected
+			if projected {
+				season.Projected += day.DegreeDays
+			} else {
+				season.ToDate += day.DegreeDays
+			}
+			season.Days = append(season.Days, day)
+		}
+		return nil
+	}
+
+	for _, response := range history {
+		if err := add(response, false); err != nil {
+			return Season{}, err
+		}
+	}
+	if err := add(forecast, true); err != nil {
+		return Season{}, err
+	}
+	sort.SliceStable(season.Days, func(i, j int) bool { return season.Days[i].Time.Before(season.Days[j].Time) })
+	return season, nil
+}
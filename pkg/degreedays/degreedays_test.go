@@ -0,0 +1,113 @@
+package degreedays_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/degreedays"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name       string
+		calculator degreedays.Calculator
+		min, max   float64
+		want       float64
+	}{
+		{"heating", degreedays.New(degreedays.Heating), 0, 10, 13},
+		{"heating above the base", degreedays.New(degreedays.Heating), 20, 30, 0},
+		{"heating single sine", degreedays.New(degreedays.Heating, degreedays.WithMethod(degreedays.SingleSine)), 0, 10, 13},
+		{"cooling", degreedays.New(degreedays.Cooling), 20, 30, 7},
+		{"cooling with a base", degreedays.New(degreedays.Cooling, degreedays.WithBase(22)), 20, 30, 3},
+		{"growing", degreedays.New(degreedays.Growing), 5, 25, 5},
+		{"growing modified", degreedays.New(degreedays.Growing, degreedays.WithMethod(degreedays.Modified)), 5, 25, 7.5},
+		{"growing modified above the cutoff", degreedays.New(degreedays.Growing, degreedays.WithMethod(degreedays.Modified)), 20, 36, 15},
+		{"growing single sine", degreedays.New(degreedays.Growing, degreedays.WithMethod(degreedays.SingleSine)), 5, 25, 6.0900},
+		{"growing single sine within the range", degreedays.New(degreedays.Growing, degreedays.WithMethod(degreedays.SingleSine)), 10, 30, 10},
+		{"growing single sine above the cutoff", degreedays.New(degreedays.Growing, degreedays.WithMethod(degreedays.SingleSine)), 30, 40, 20},
+		{"growing single sine below the base", degreedays.New(degreedays.Growing, degreedays.WithMethod(degreedays.SingleSine)), 0, 8, 0},
+	}
+	for _, tt := range tests {
+		require.InDelta(t, tt.want, tt.calculator.Compute(tt.min, tt.max), 1e-4, tt.name)
+	}
+	require.Equal(t, "growing degree days", degreedays.New(degreedays.Growing).Kind().String())
+	require.Equal(t, "single sine", degreedays.SingleSine.String())
+}
+
+// daily returns a response with a daily block of the minimum and maximum temperatures from start
+func daily(units string, start time.Time, temperatures ...[2]float64) *models.ForecastResponse {
+	response := &models.ForecastResponse{Timezone: start.Location().String(), Flags: &models.Flags{Units: units}, Daily: &models.DataBlock{}}
+	for i, t := range temperatures {
+		response.Daily.Data = append(response.Daily.Data, models.DataPoint{
+			Time:           start.AddDate(0, 0, i).Unix(),
+			TemperatureMin: t[0],
+			TemperatureMax: t[1],
+		})
+	}
+	return response
+}
+
+func TestDays(t *testing.T) {
+	start := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	forecast := daily("us", start, [2]float64{32, 50}, [2]float64{50, 68})
+	forecast.Daily.Data = append(forecast.Daily.Data, models.DataPoint{Time: start.AddDate(0, 0, 2).Unix()})
+	forecast.Daily.Data[2].SetAbsent("temperatureMax")
+
+	days, err := degreedays.New(degreedays.Heating).Forecast(forecast)
+	require.NoError(t, err)
+	require.Len(t, days, 2, "days without a maximum are skipped")
+	require.InDelta(t, 23.4, days[0].DegreeDays, 1e-9, "13 °C days are 23.4 °F days")
+	require.InDelta(t, 5.4, days[1].DegreeDays, 1e-9)
+	require.Equal(t, 32.0, days[0].Min)
+
+	_, err = degreedays.New(degreedays.Heating).Days(forecast.Daily.Data, "metric")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestForecastFromHourly(t *testing.T) {
+	start := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	history := &models.ForecastResponse{Timezone: "UTC", Flags: &models.Flags{Units: "si"}, Hourly: &models.DataBlock{}}
+	for i := 0; i < 24; i++ {
+		temperature := 12.0
+		if i == 15 {
+			temperature = 28
+		}
+		history.Hourly.Data = append(history.Hourly.Data, models.DataPoint{Time: start.Add(time.Duration(i) * time.Hour).Unix(), Temperature: temperature})
+	}
+	days, err := degreedays.New(degreedays.Growing).Forecast(history)
+	require.NoError(t, err)
+	require.Len(t, days, 1)
+	require.Equal(t, start, days[0].Time.UTC())
+	require.InDelta(t, 10, days[0].DegreeDays, 1e-9)
+
+	_, err = degreedays.New(degreedays.Growing).Forecast(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoDaily)
+}
+
+func TestSeason(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	today := time.Date(2024, 5, 15, 0, 0, 0, 0, chicago)
+
+	// Two TimeMachine days in SI units, the second also covered by the forecast
+	history := []*models.ForecastResponse{
+		daily("si", today.AddDate(0, 0, -2), [2]float64{10, 20}),
+		daily("si", today.AddDate(0, 0, -1), [2]float64{12, 24}),
+	}
+	forecast := daily("us", today.AddDate(0, 0, -1), [2]float64{32, 32}, [2]float64{50, 86}, [2]float64{59, 95})
+
+	season, err := degreedays.New(degreedays.Growing).Season(forecast, history...)
+	require.NoError(t, err)
+	require.Len(t, season.Days, 4)
+	require.Equal(t, today.AddDate(0, 0, -2), season.Days[0].Time)
+	require.False(t, season.Days[1].Projected)
+	require.InDelta(t, 75.2, season.Days[1].Max, 1e-9, "history is converted to the forecast's units")
+	require.True(t, season.Days[2].Projected)
+
+	// 5 + 8 °C days to date, then 10 + 15 °C days, in °F days
+	require.InDelta(t, 23.4, season.ToDate, 1e-9)
+	require.InDelta(t, 45, season.Projected, 1e-9)
+	require.InDelta(t, 68.4, season.Total(), 1e-9)
+}
@@ -0,0 +1,270 @@
+// Package extremes flags extreme weather events, such as heat waves, cold snaps and high wind
+// periods, in the data points of forecasts and of TimeMachine history.
+package extremes
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/aggregate"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Event is a run of consecutive points that meets a definition
+type Event struct {
+	Name     string
+	Field    string // the field the event was detected on
+	Start    time.Time
+	End      time.Time // end of the last point's period
+	Peak     float64   // most extreme value of the field, in the points' units
+	PeakTime time.Time
+	Severity models.Severity
+	Points   int
+}
+
+// Duration returns the time from the start to the end of the event
+func (e Event) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Detector finds events in time-ordered points whose values are in a units system
+type Detector interface {
+	Detect(points []models.DataPoint, units string) ([]Event, error)
+}
+
+// DetectorFunc adapts a function to a Detector
+type DetectorFunc func(points []models.DataPoint, units string) ([]Event, error)
+
+// Detect calls f
+func (f DetectorFunc) Detect(points []models.DataPoint, units string) ([]Event, error) {
+	return f(points, units)
+}
+
+// Level raises the severity of events whose peak reaches Value, in SI units
+type Level struct {
+	Value    float64
+	Severity models.Severity
+}
+
+// Threshold detects runs of consecutive points whose field is above Value, or below it when Below is
+// set. Events have advisory severity unless their peak reaches one of the Levels.
+type Threshold struct {
+	Name string
+	// Fields are the candidate fields; the first one with a value in the points is used,
+	// so a definition can cover daily points and hourly ones
+	Fields      []string
+	Value       float64 // SI units
+	Below       bool
+	MinPoints   int           // consecutive points an event needs, at least 1
+	MinDuration time.Duration // duration an event needs
+	Levels      []Level
+}
+
+// field returns the first candidate field with a value in the points
+func (d Threshold) field(points []models.DataPoint) (models.Field, bool) {
+	for _, name := range d.Fields {
+		field, ok := models.LookupField(name)
+		if !ok || !field.IsNumeric() {
+			continue
+		}
+		for i := range points {
+			if _, ok := points[i].Value(name); ok {
+				return field, true
+			}
+		}
+	}
+	return models.Field{}, false
+}
+
+// beyond reports whether value is past limit in the direction of the definition
+func (d Threshold) beyond(value, limit float64, inclusive bool) bool {
+	if d.Below {
+		return value < limit || inclusive && value == limit
+	}
+	return value > limit || inclusive && value == limit
+}
+
+// Detect finds the events in points, which must be in time order
+func (d Threshold) Detect(points []models.DataPoint, units string) ([]Event, error) {
+	field, ok := d.field(points)
+	if !ok {
+		return nil, nil
+	}
+
+	var events []Event
+	start, peak := -1, -1
+	var peakSI float64
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		event := Event{
+			Name:     d.Name,
+			Field:    field.Name,
+			Start:    points[start].LocalTime(),
+			End:      points[end].LocalTime().Add(span(points, end)),
+			PeakTime: points[peak].LocalTime(),
+			Severity: models.SeverityAdvisory,
+			Points:   end - start + 1,
+		}
+		event.Peak, _ = points[peak].Value(field.Name)
+		for _, level := range d.Levels {
+			if d.beyond(peakSI, level.Value, true) && level.Severity > event.Severity {
+				event.Severity = level.Severity
+			}
+		}
+		if event.Points >= d.MinPoints && event.Duration() >= d.MinDuration {
+			events = append(events, event)
+		}
+		start = -1
+	}
+
+	for i := range points {
+		value, ok := points[i].Value(field.Name)
+		if ok {
+			var err error
+			if value, err = models.ConvertValue(field.Quantity, value, units, models.UnitsSI); err != nil {
+				return nil, err
+			}
+		}
+		if !ok || !d.beyond(value, d.Value, false) {
+			flush(i - 1)
+			continue
+		}
+		if start < 0 {
+			start, peak, peakSI = i, i, value
+		} else if d.beyond(value, peakSI, false) {
+			peak, peakSI = i, value
+		}
+	}
+	flush(len(points) - 1)
+	return events, nil
+}
+
+// span returns the period of the i-th point: the gap to the next point, or for the last point the gap before it
+func span(points []models.DataPoint, i int) time.Duration {
+	switch {
+	case i+1 < len(points):
+		return time.Duration(points[i+1].Time-points[i].Time) * time.Second
+	case i > 0:
+		return time.Duration(points[i].Time-points[i-1].Time) * time.Second
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// HeatWave detects three or more consecutive days with a high above 32 °C, a watch from 35 °C and a warning from 38 °C
+func HeatWave() Threshold {
+	return Threshold{
+		Name:      "heat wave",
+		Fields:    []string{"temperatureHigh", "temperatureMax"},
+		Value:     32,
+		MinPoints: 3,
+		Levels:    []Level{{35, models.SeverityWatch}, {38, models.SeverityWarning}},
+	}
+}
+
+// ColdSnap detects three or more consecutive days with a low below -15 °C, a watch from -25 °C and a warning from -35 °C
+func ColdSnap() Threshold {
+	return Threshold{
+		Name:      "cold snap",
+		Fields:    []string{"temperatureLow", "temperatureMin"},
+		Value:     -15,
+		Below:     true,
+		MinPoints: 3,
+		Levels:    []Level{{-25, models.SeverityWatch}, {-35, models.SeverityWarning}},
+	}
+}
+
+// HeavyPrecipitation detects days with more than 25 mm of precipitation, a watch from 50 mm and a warning from 100 mm
+func HeavyPrecipitation() Threshold {
+	return Threshold{
+		Name:   "heavy precipitation",
+		Fields: []string{"precipAccumulation"},
+		Value:  2.5, // cm
+		Levels: []Level{{5, models.SeverityWatch}, {10, models.SeverityWarning}},
+	}
+}
+
+// Freeze detects days with a minimum below 0 °C, a watch from -2 °C and a warning from -5 °C
+func Freeze() Threshold {
+	return Threshold{
+		Name:   "freeze",
+		Fields: []string{"temperatureMin", "temperatureLow", "temperature"},
+		Value:  0,
+		Below:  true,
+		Levels: []Level{{-2, models.SeverityWatch}, {-5, models.SeverityWarning}},
+	}
+}
+
+// HighWind detects periods of gusts above gale force (17.2 m/s), a watch from storm force (24.5 m/s) and a
+// warning from hurricane force (32.7 m/s). It works on daily and hourly points alike.
+func HighWind() Threshold {
+	return Threshold{
+		Name:   "high wind",
+		Fields: []string{"windGust", "windSpeed"},
+		Value:  17.2,
+		Levels: []Level{{24.5, models.SeverityWatch}, {32.7, models.SeverityWarning}},
+	}
+}
+
+// Defaults returns the built-in daily definitions
+func Defaults() []Detector {
+	return []Detector{HeatWave(), ColdSnap(), HeavyPrecipitation(), Freeze(), HighWind()}
+}
+
+// Detect runs the detectors, or the Defaults when there are none, over the points and returns
+// the events ordered by start. The points need not be sorted.
+func Detect(points []models.DataPoint, units string, detectors ...Detector) ([]Event, error) {
+	if len(detectors) == 0 {
+		detectors = Defaults()
+	}
+	sorted := make([]models.DataPoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+
+	var events []Event
+	for _, detector := range detectors {
+		found, err := detector.Detect(sorted, units)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, found...)
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	return events, nil
+}
+
+// DetectForecast runs the detectors over the forecast's daily block, or over its hourly block rolled up
+// into days when it has no daily block, as TimeMachine responses for past days may not. Times are in
+// the forecast's timezone.
+func DetectForecast(forecast *models.ForecastResponse, detectors ...Detector) ([]Event, error) {
+	loc := forecast.Location()
+	var days []models.DataPoint
+	if forecast.Daily != nil && len(forecast.Daily.Data) > 0 {
+		days = make([]models.DataPoint, len(forecast.Daily.Data))
+		copy(days, forecast.Daily.Data)
+		for i := range days {
+			days[i].SetLocation(loc)
+		}
+	} else {
+		daily, err := aggregate.Daily(forecast)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", models.ErrNoDaily, err)
+		}
+		days = daily.Data
+	}
+	return Detect(days, forecast.Units(), detectors...)
+}
+
+// Worst returns the most severe event, preferring the longest on ties; ok is false if there are none
+func Worst(events []Event) (worst Event, ok bool) {
+	var longest time.Duration
+	for _, e := range events {
+		if !ok || e.Severity > worst.Severity || e.Severity == worst.Severity && e.Duration() > longest {
+			worst, longest, ok = e, e.Duration(), true
+		}
+	}
+	return worst, ok
+}
@@ -0,0 +1,147 @@
+package extremes_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/extremes"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+// days returns daily points from start with the given highs in °C and lows and minima 15 °C below them
+func days(highs ...float64) []models.DataPoint {
+	var points []models.DataPoint
+	for i, high := range highs {
+		points = append(points, models.DataPoint{
+			Time:            start.AddDate(0, 0, i).Unix(),
+			TemperatureHigh: high,
+			TemperatureLow:  high - 15,
+			TemperatureMin:  high - 15,
+		})
+	}
+	return points
+}
+
+func TestHeatWave(t *testing.T) {
+	points := days(30, 33, 36, 34, 31, 33, 34, 30)
+	events, err := extremes.Detect(points, "si", extremes.HeatWave())
+	require.NoError(t, err)
+	require.Len(t, events, 1, "two days above 32 °C are not a heat wave")
+
+	event := events[0]
+	require.Equal(t, "heat wave", event.Name)
+	require.Equal(t, "temperatureHigh", event.Field)
+	require.Equal(t, start.AddDate(0, 0, 1), event.Start.UTC())
+	require.Equal(t, start.AddDate(0, 0, 4), event.End.UTC())
+	require.Equal(t, 72*time.Hour, event.Duration())
+	require.Equal(t, 36.0, event.Peak)
+	require.Equal(t, start.AddDate(0, 0, 2), event.PeakTime.UTC())
+	require.Equal(t, models.SeverityWatch, event.Severity)
+	require.Equal(t, 3, event.Points)
+
+	// The same days in Fahrenheit, unsorted
+	us := days(86, 91.4, 100.4, 93.2)
+	us[0], us[3] = us[3], us[0]
+	events, err = extremes.Detect(us, "us", extremes.HeatWave())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, 100.4, events[0].Peak)
+	require.Equal(t, models.SeverityWarning, events[0].Severity, "38 °C is a warning")
+}
+
+func TestDefaults(t *testing.T) {
+	points := days(20, 3, 2, 12)
+	points[1].WindGust = 26
+	points[2].PrecipAccumulation = 3
+	points[3].SetAbsent("temperatureMin")
+
+	events, err := extremes.Detect(points, "si")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	names := []string{events[0].Name, events[1].Name, events[2].Name}
+	require.ElementsMatch(t, []string{"freeze", "high wind", "heavy precipitation"}, names)
+	for _, event := range events {
+		switch event.Name {
+		case "freeze":
+			require.Equal(t, -13.0, event.Peak)
+			require.Equal(t, 2, event.Points)
+			require.Equal(t, models.SeverityWarning, event.Severity)
+		case "high wind":
+			require.Equal(t, models.SeverityWatch, event.Severity)
+		case "heavy precipitation":
+			require.Equal(t, models.SeverityAdvisory, event.Severity)
+		}
+	}
+
+	worst, ok := extremes.Worst(events)
+	require.True(t, ok)
+	require.Equal(t, "freeze", worst.Name)
+	_, ok = extremes.Worst(nil)
+	require.False(t, ok)
+
+	_, err = extremes.Detect(points, "metric")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestCustomDetectors(t *testing.T) {
+	// Gusts above 15 m/s for at least three hours, on hourly points
+	var hours []models.DataPoint
+	for i, gust := range []float64{12, 16, 18, 17, 14, 16, 13} {
+		hours = append(hours, models.DataPoint{Time: start.Add(time.Duration(i) * time.Hour).Unix(), WindGust: gust})
+	}
+	gusty := extremes.Threshold{Name: "gusty", Fields: []string{"windGust"}, Value: 15, MinDuration: 3 * time.Hour}
+	events, err := extremes.Detect(hours, "si", gusty)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, start.Add(time.Hour), events[0].Start.UTC())
+	require.Equal(t, start.Add(4*time.Hour), events[0].End.UTC())
+
+	calls := 0
+	counter := extremes.DetectorFunc(func(points []models.DataPoint, units string) ([]extremes.Event, error) {
+		calls++
+		require.Equal(t, "si", units)
+		return []extremes.Event{{Name: "first", Start: points[0].LocalTime()}}, nil
+	})
+	events, err = extremes.Detect(hours, "si", counter, gusty)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Len(t, events, 2)
+	require.Equal(t, "first", events[0].Name)
+}
+
+func TestDetectForecast(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	require.NoError(t, err)
+
+	// A TimeMachine day with only an hourly block, freezing before dawn
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, auckland)
+	history := &models.ForecastResponse{Timezone: "Pacific/Auckland", Flags: &models.Flags{Units: "si"}, Hourly: &models.DataBlock{}}
+	for i := 0; i < 24; i++ {
+		temperature := 4.0
+		if i < 6 {
+			temperature = -3
+		}
+		history.Hourly.Data = append(history.Hourly.Data, models.DataPoint{Time: day.Add(time.Duration(i) * time.Hour).Unix(), Temperature: temperature})
+	}
+	events, err := extremes.DetectForecast(history, extremes.Freeze())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "temperatureMin", events[0].Field)
+	require.Equal(t, day, events[0].Start)
+	require.Equal(t, models.SeverityWatch, events[0].Severity)
+
+	// The daily block is used when there is one, in the forecast's timezone
+	forecast := &models.ForecastResponse{Timezone: "Pacific/Auckland", Flags: &models.Flags{Units: "si"}, Daily: &models.DataBlock{Data: days(35, 35, 35)}}
+	events, err = extremes.DetectForecast(forecast)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, auckland, events[0].Start.Location())
+
+	_, err = extremes.DetectForecast(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoDaily)
+	require.ErrorIs(t, err, models.ErrNoHourly)
+}
@@ -2,11 +2,21 @@ package geocoding
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 )
 
+// Sentinel errors of the geocoding requests, wrapped by the errors they return
+var (
+	ErrCreateRequest = errors.New("error creating request")
+	ErrRequest       = errors.New("error making request")
+	ErrStatus        = errors.New("API request failed with status code")
+	ErrDecode        = errors.New("error decoding response")
+	ErrNoResults     = errors.New("no results found for the given address")
+)
+
 type GeocodingResult struct {
 	DisplayName string `json:"display_name"`
 	Address     struct {
@@ -22,24 +32,24 @@ func ReverseGeocode(latitude, longitude float64) (*GeocodingResult, error) {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
 	}
 	req.Header.Set("User-Agent", "PirateWeatherGoSDK/1.0")
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrRequest, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: %d", ErrStatus, resp.StatusCode)
 	}
 
 	var result GeocodingResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
 	}
 
 	return &result, nil
@@ -56,28 +66,28 @@ func ForwardGeocode(address string) (*ForwardGeocodingResult, error) {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
 	}
 	req.Header.Set("User-Agent", "PirateWeatherGoSDK/1.0")
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrRequest, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: %d", ErrStatus, resp.StatusCode)
 	}
 
 	var results []ForwardGeocodingResult
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
 	}
 
 	if len(results) == 0 {
-		return nil, fmt.Errorf("no results found for the given address")
+		return nil, ErrNoResults
 	}
 
 	return &results[0], nil
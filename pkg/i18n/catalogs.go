@@ -0,0 +1,557 @@
+package i18n
+
+import "strings"
+
+// compass32 are the English names of the points of a 32-point compass rose
+var compass32 = []string{
+	"N", "NbE", "NNE", "NEbN", "NE", "NEbE", "ENE", "EbN",
+	"E", "EbS", "ESE", "SEbE", "SE", "SEbS", "SSE", "SbE",
+	"S", "SbW", "SSW", "SWbS", "SW", "SWbW", "WSW", "WbS",
+	"W", "WbN", "WNW", "NWbW", "NW", "NWbN", "NNW", "NbW",
+}
+
+// compass translates the compass points by replacing the letters for east, west and "by"
+func compass(east, west, by string) Catalog {
+	replacer := strings.NewReplacer("E", east, "W", west, "b", by)
+	points := make(Catalog, len(compass32))
+	for _, point := range compass32 {
+		points[point] = replacer.Replace(point)
+	}
+	return points
+}
+
+// merge combines catalogs into one
+func merge(catalogs ...Catalog) Catalog {
+	merged := make(Catalog)
+	for _, c := range catalogs {
+		for message, translation := range c {
+			merged[message] = translation
+		}
+	}
+	return merged
+}
+
+var german = merge(compass("O", "W", "z"), Catalog{
+	// Errors, by the text of their sentinels
+	"error creating request":                                       "Fehler beim Erstellen der Anfrage",
+	"error making request":                                         "Fehler beim Senden der Anfrage",
+	"error decoding response":                                      "Fehler beim Dekodieren der Antwort",
+	"rate limit exceeded":                                          "Ratenlimit überschritten",
+	"bad request: invalid latitude or longitude":                   "ungültige Anfrage: ungültiger Breiten- oder Längengrad",
+	"unauthorized: invalid API key or insufficient permissions":    "nicht autorisiert: ungültiger API-Schlüssel oder unzureichende Berechtigungen",
+	"not found: invalid route or missing latitude/longitude":       "nicht gefunden: ungültige Route oder fehlender Breiten-/Längengrad",
+	"rate limit exceeded: API key has hit the quota for the month": "Ratenlimit überschritten: der API-Schlüssel hat das Monatskontingent ausgeschöpft",
+	"internal server error":                                        "interner Serverfehler",
+	"API request failed with status code":                          "API-Anfrage fehlgeschlagen, Statuscode",
+	"no results found for the given address":                       "keine Ergebnisse für die angegebene Adresse",
+	"forecast has no minutely block":                               "die Vorhersage enthält keinen minütlichen Block",
+	"forecast has no hourly block":                                 "die Vorhersage enthält keinen stündlichen Block",
+	"forecast has no daily block":                                  "die Vorhersage enthält keinen täglichen Block",
+	"no data point for the requested time":                         "kein Datenpunkt für die angefragte Zeit",
+	"unknown units system":                                         "unbekanntes Einheitensystem",
+	"series has no data points":                                    "die Reihe enthält keine Datenpunkte",
+	"time outside the series":                                      "Zeit außerhalb der Reihe",
+	"not a numeric field":                                          "kein numerisches Feld",
+	"value missing":                                                "Wert fehlt",
+	"interval must be positive":                                    "das Intervall muss positiv sein",
+	"missing input":                                                "fehlende Eingabe",
+	"insufficient data":                                            "unzureichende Daten",
+	"estimates are not consecutive days":                           "die Schätzungen sind keine aufeinanderfolgenden Tage",
+	"hours must be a positive divisor of 24":                       "die Stunden müssen ein positiver Teiler von 24 sein",
+	"unsupported temperature conversion":                           "nicht unterstützte Temperaturumrechnung",
+	"no conversion":                                                "keine Umrechnung",
+
+	// Errors, by the formats of their types
+	"API Error: %v":                           "API-Fehler: %v",
+	"Rate Limit Error: %v":                    "Ratenlimit-Fehler: %v",
+	"JSON Error: %v":                          "JSON-Fehler: %v",
+	"API request failed after %d retries":     "API-Anfrage nach %d Wiederholungen fehlgeschlagen",
+	"API request failed after %d retries: %v": "API-Anfrage nach %d Wiederholungen fehlgeschlagen: %v",
+	"unknown unit: %s":                        "unbekannte Einheit: %s",
+	"%s (%s) is not a %s unit":                "%s (%s) ist keine Einheit für %s",
+	"cannot convert %s (%s) to %s (%s)":       "%s (%s) lässt sich nicht in %s (%s) umrechnen",
+
+	// Dimensions and units
+	"temperature":           "Temperatur",
+	"speed":                 "Geschwindigkeit",
+	"length":                "Länge",
+	"pressure":              "Druck",
+	"precipitation rate":    "Niederschlagsrate",
+	"degree Celsius":        "Grad Celsius",
+	"degree Fahrenheit":     "Grad Fahrenheit",
+	"kelvin":                "Kelvin",
+	"metre per second":      "Meter pro Sekunde",
+	"kilometre per hour":    "Kilometer pro Stunde",
+	"mile per hour":         "Meile pro Stunde",
+	"knot":                  "Knoten",
+	"millimetre":            "Millimeter",
+	"centimetre":            "Zentimeter",
+	"metre":                 "Meter",
+	"kilometre":             "Kilometer",
+	"inch":                  "Zoll",
+	"foot":                  "Fuß",
+	"mile":                  "Meile",
+	"hectopascal":           "Hektopascal",
+	"pascal":                "Pascal",
+	"kilopascal":            "Kilopascal",
+	"inch of mercury":       "Zoll Quecksilbersäule",
+	"millimetre of mercury": "Millimeter Quecksilbersäule",
+	"millimetre per hour":   "Millimeter pro Stunde",
+	"inch per hour":         "Zoll pro Stunde",
+
+	// Wind
+	"calm":            "Windstille",
+	"light air":       "leiser Zug",
+	"light breeze":    "leichte Brise",
+	"gentle breeze":   "schwache Brise",
+	"moderate breeze": "mäßige Brise",
+	"fresh breeze":    "frische Brise",
+	"strong breeze":   "starker Wind",
+	"near gale":       "steifer Wind",
+	"gale":            "stürmischer Wind",
+	"strong gale":     "Sturm",
+	"storm":           "schwerer Sturm",
+	"violent storm":   "orkanartiger Sturm",
+	"hurricane force": "Orkan",
+	"sustained":       "gleichmäßig",
+	"gusty":           "böig",
+
+	// Risk levels, intensity classes and alert severities
+	"none":      "keine",
+	"low":       "gering",
+	"moderate":  "mäßig",
+	"high":      "hoch",
+	"very high": "sehr hoch",
+	"extreme":   "extrem",
+	"light":     "leicht",
+	"heavy":     "stark",
+	"advisory":  "Hinweis",
+	"watch":     "Vorwarnung",
+	"warning":   "Warnung",
+	"unknown":   "unbekannt",
+
+	// Extreme events
+	"heat wave": "Hitzewelle",
+	"cold snap": "Kältewelle",
+	"freeze":    "Frost",
+	"high wind": "Sturm",
+
+	// Pressure tendency
+	"steady":               "gleichbleibend",
+	"rising slowly":        "langsam steigend",
+	"rising":               "steigend",
+	"rising quickly":       "schnell steigend",
+	"rising very rapidly":  "sehr schnell steigend",
+	"falling slowly":       "langsam fallend",
+	"falling":              "fallend",
+	"falling quickly":      "schnell fallend",
+	"falling very rapidly": "sehr schnell fallend",
+
+	// Sun and moon
+	"night":                 "Nacht",
+	"astronomical twilight": "astronomische Dämmerung",
+	"nautical twilight":     "nautische Dämmerung",
+	"civil twilight":        "bürgerliche Dämmerung",
+	"daylight":              "Tageslicht",
+	"new moon":              "Neumond",
+	"waxing crescent":       "zunehmende Sichel",
+	"first quarter":         "erstes Viertel",
+	"waxing gibbous":        "zunehmender Mond",
+	"full moon":             "Vollmond",
+	"waning gibbous":        "abnehmender Mond",
+	"last quarter":          "letztes Viertel",
+	"waning crescent":       "abnehmende Sichel",
+
+	// Conditions
+	"rain":                "Regen",
+	"snow":                "Schnee",
+	"sleet":               "Schneeregen",
+	"precipitation":       "Niederschlag",
+	"light rain":          "leichter Regen",
+	"heavy rain":          "starker Regen",
+	"light snow":          "leichter Schneefall",
+	"heavy snow":          "starker Schneefall",
+	"light sleet":         "leichter Schneeregen",
+	"heavy sleet":         "starker Schneeregen",
+	"light precipitation": "leichter Niederschlag",
+	"heavy precipitation": "starker Niederschlag",
+	"foggy":               "neblig",
+	"clear":               "klar",
+	"partly cloudy":       "teilweise bewölkt",
+	"mostly cloudy":       "überwiegend bewölkt",
+	"overcast":            "bedeckt",
+	"windy and %s":        "windig und %s",
+
+	// Times
+	"today":              "heute",
+	"tomorrow":           "morgen",
+	"on %s":              "am %s",
+	"this morning":       "heute Morgen",
+	"this afternoon":     "heute Nachmittag",
+	"this evening":       "heute Abend",
+	"tonight":            "heute Nacht",
+	"overnight":          "über Nacht",
+	"tomorrow morning":   "morgen früh",
+	"tomorrow afternoon": "morgen Nachmittag",
+	"tomorrow evening":   "morgen Abend",
+	"tomorrow night":     "morgen Nacht",
+	"%s morning":         "%smorgen",
+	"%s afternoon":       "%snachmittag",
+	"%s evening":         "%sabend",
+	"%s night":           "%snacht",
+	"in the morning":     "am Morgen",
+	"in the afternoon":   "am Nachmittag",
+	"in the evening":     "am Abend",
+	"in %d min":          "in %d Minuten",
+	" and ":              " und ",
+})
+
+var spanish = merge(compass("E", "O", "c"), Catalog{
+	// Errors, by the text of their sentinels
+	"error creating request":                                       "error al crear la solicitud",
+	"error making request":                                         "error al enviar la solicitud",
+	"error decoding response":                                      "error al decodificar la respuesta",
+	"rate limit exceeded":                                          "límite de solicitudes superado",
+	"bad request: invalid latitude or longitude":                   "solicitud incorrecta: latitud o longitud no válida",
+	"unauthorized: invalid API key or insufficient permissions":    "no autorizado: clave de API no válida o permisos insuficientes",
+	"not found: invalid route or missing latitude/longitude":       "no encontrado: ruta no válida o falta la latitud/longitud",
+	"rate limit exceeded: API key has hit the quota for the month": "límite de solicitudes superado: la clave de API ha agotado la cuota del mes",
+	"internal server error":                                        "error interno del servidor",
+	"API request failed with status code":                          "la solicitud a la API falló con el código de estado",
+	"no results found for the given address":                       "no se encontraron resultados para la dirección indicada",
+	"forecast has no minutely block":                               "el pronóstico no tiene bloque por minuto",
+	"forecast has no hourly block":                                 "el pronóstico no tiene bloque por hora",
+	"forecast has no daily block":                                  "el pronóstico no tiene bloque diario",
+	"no data point for the requested time":                         "no hay datos para la hora solicitada",
+	"unknown units system":                                         "sistema de unidades desconocido",
+	"series has no data points":                                    "la serie no tiene datos",
+	"time outside the series":                                      "hora fuera de la serie",
+	"not a numeric field":                                          "no es un campo numérico",
+	"value missing":                                                "falta el valor",
+	"interval must be positive":                                    "el intervalo debe ser positivo",
+	"missing input":                                                "faltan datos de entrada",
+	"insufficient data":                                            "datos insuficientes",
+	"estimates are not consecutive days":                           "las estimaciones no son días consecutivos",
+	"hours must be a positive divisor of 24":                       "las horas deben ser un divisor positivo de 24",
+	"unsupported temperature conversion":                           "conversión de temperatura no admitida",
+	"no conversion":                                                "sin conversión",
+
+	// Errors, by the formats of their types
+	"API Error: %v":                           "Error de la API: %v",
+	"Rate Limit Error: %v":                    "Error de límite de solicitudes: %v",
+	"JSON Error: %v":                          "Error de JSON: %v",
+	"API request failed after %d retries":     "la solicitud a la API falló tras %d reintentos",
+	"API request failed after %d retries: %v": "la solicitud a la API falló tras %d reintentos: %v",
+	"unknown unit: %s":                        "unidad desconocida: %s",
+	"%s (%s) is not a %s unit":                "%s (%s) no es una unidad de %s",
+	"cannot convert %s (%s) to %s (%s)":       "no se puede convertir %s (%s) a %s (%s)",
+
+	// Dimensions and units
+	"temperature":           "temperatura",
+	"speed":                 "velocidad",
+	"length":                "longitud",
+	"pressure":              "presión",
+	"precipitation rate":    "intensidad de precipitación",
+	"degree Celsius":        "grado Celsius",
+	"degree Fahrenheit":     "grado Fahrenheit",
+	"kelvin":                "kelvin",
+	"metre per second":      "metro por segundo",
+	"kilometre per hour":    "kilómetro por hora",
+	"mile per hour":         "milla por hora",
+	"knot":                  "nudo",
+	"millimetre":            "milímetro",
+	"centimetre":            "centímetro",
+	"metre":                 "metro",
+	"kilometre":             "kilómetro",
+	"inch":                  "pulgada",
+	"foot":                  "pie",
+	"mile":                  "milla",
+	"hectopascal":           "hectopascal",
+	"pascal":                "pascal",
+	"kilopascal":            "kilopascal",
+	"inch of mercury":       "pulgada de mercurio",
+	"millimetre of mercury": "milímetro de mercurio",
+	"millimetre per hour":   "milímetro por hora",
+	"inch per hour":         "pulgada por hora",
+
+	// Wind
+	"calm":            "calma",
+	"light air":       "ventolina",
+	"light breeze":    "flojito",
+	"gentle breeze":   "flojo",
+	"moderate breeze": "bonancible",
+	"fresh breeze":    "fresquito",
+	"strong breeze":   "fresco",
+	"near gale":       "frescachón",
+	"gale":            "temporal",
+	"strong gale":     "temporal fuerte",
+	"storm":           "temporal duro",
+	"violent storm":   "temporal muy duro",
+	"hurricane force": "temporal huracanado",
+	"sustained":       "sostenido",
+	"gusty":           "racheado",
+
+	// Risk levels, intensity classes and alert severities
+	"none":      "ninguno",
+	"low":       "bajo",
+	"moderate":  "moderado",
+	"high":      "alto",
+	"very high": "muy alto",
+	"extreme":   "extremo",
+	"light":     "ligero",
+	"heavy":     "fuerte",
+	"advisory":  "aviso",
+	"watch":     "vigilancia",
+	"warning":   "alerta",
+	"unknown":   "desconocido",
+
+	// Extreme events
+	"heat wave": "ola de calor",
+	"cold snap": "ola de frío",
+	"freeze":    "helada",
+	"high wind": "viento fuerte",
+
+	// Pressure tendency
+	"steady":               "estable",
+	"rising slowly":        "subiendo lentamente",
+	"rising":               "subiendo",
+	"rising quickly":       "subiendo rápidamente",
+	"rising very rapidly":  "subiendo muy rápidamente",
+	"falling slowly":       "bajando lentamente",
+	"falling":              "bajando",
+	"falling quickly":      "bajando rápidamente",
+	"falling very rapidly": "bajando muy rápidamente",
+
+	// Sun and moon
+	"night":                 "noche",
+	"astronomical twilight": "crepúsculo astronómico",
+	"nautical twilight":     "crepúsculo náutico",
+	"civil twilight":        "crepúsculo civil",
+	"daylight":              "luz del día",
+	"new moon":              "luna nueva",
+	"waxing crescent":       "luna creciente",
+	"first quarter":         "cuarto creciente",
+	"waxing gibbous":        "gibosa creciente",
+	"full moon":             "luna llena",
+	"waning gibbous":        "gibosa menguante",
+	"last quarter":          "cuarto menguante",
+	"waning crescent":       "luna menguante",
+
+	// Conditions
+	"rain":                "lluvia",
+	"snow":                "nieve",
+	"sleet":               "aguanieve",
+	"precipitation":       "precipitación",
+	"light rain":          "lluvia ligera",
+	"heavy rain":          "lluvia fuerte",
+	"light snow":          "nieve ligera",
+	"heavy snow":          "nieve fuerte",
+	"light sleet":         "aguanieve ligera",
+	"heavy sleet":         "aguanieve fuerte",
+	"light precipitation": "precipitación ligera",
+	"heavy precipitation": "precipitación fuerte",
+	"foggy":               "niebla",
+	"clear":               "despejado",
+	"partly cloudy":       "parcialmente nublado",
+	"mostly cloudy":       "mayormente nublado",
+	"overcast":            "cubierto",
+	"windy and %s":        "ventoso y %s",
+
+	// Times
+	"today":              "hoy",
+	"tomorrow":           "mañana",
+	"on %s":              "el %s",
+	"this morning":       "esta mañana",
+	"this afternoon":     "esta tarde",
+	"this evening":       "esta noche",
+	"tonight":            "esta noche",
+	"overnight":          "durante la noche",
+	"tomorrow morning":   "mañana por la mañana",
+	"tomorrow afternoon": "mañana por la tarde",
+	"tomorrow evening":   "mañana por la noche",
+	"tomorrow night":     "mañana por la noche",
+	"%s morning":         "el %s por la mañana",
+	"%s afternoon":       "el %s por la tarde",
+	"%s evening":         "el %s por la noche",
+	"%s night":           "el %s por la noche",
+	"in the morning":     "por la mañana",
+	"in the afternoon":   "por la tarde",
+	"in the evening":     "por la noche",
+	"in %d min":          "en %d min",
+	" and ":              " y ",
+})
+
+var french = merge(compass("E", "O", "q"), Catalog{
+	// Errors, by the text of their sentinels
+	"error creating request":                                       "erreur lors de la création de la requête",
+	"error making request":                                         "erreur lors de l'envoi de la requête",
+	"error decoding response":                                      "erreur lors du décodage de la réponse",
+	"rate limit exceeded":                                          "limite de requêtes dépassée",
+	"bad request: invalid latitude or longitude":                   "requête invalide: latitude ou longitude invalide",
+	"unauthorized: invalid API key or insufficient permissions":    "non autorisé: clé d'API invalide ou autorisations insuffisantes",
+	"not found: invalid route or missing latitude/longitude":       "introuvable: route invalide ou latitude/longitude manquante",
+	"rate limit exceeded: API key has hit the quota for the month": "limite de requêtes dépassée: la clé d'API a atteint le quota du mois",
+	"internal server error":                                        "erreur interne du serveur",
+	"API request failed with status code":                          "échec de la requête à l'API avec le code d'état",
+	"no results found for the given address":                       "aucun résultat pour l'adresse indiquée",
+	"forecast has no minutely block":                               "la prévision n'a pas de bloc par minute",
+	"forecast has no hourly block":                                 "la prévision n'a pas de bloc horaire",
+	"forecast has no daily block":                                  "la prévision n'a pas de bloc quotidien",
+	"no data point for the requested time":                         "aucune donnée pour l'heure demandée",
+	"unknown units system":                                         "système d'unités inconnu",
+	"series has no data points":                                    "la série n'a pas de données",
+	"time outside the series":                                      "heure hors de la série",
+	"not a numeric field":                                          "pas un champ numérique",
+	"value missing":                                                "valeur manquante",
+	"interval must be positive":                                    "l'intervalle doit être positif",
+	"missing input":                                                "donnée d'entrée manquante",
+	"insufficient data":                                            "données insuffisantes",
+	"estimates are not consecutive days":                           "les estimations ne sont pas des jours consécutifs",
+	"hours must be a positive divisor of 24":                       "les heures doivent être un diviseur positif de 24",
+	"unsupported temperature conversion":                           "conversion de température non prise en charge",
+	"no conversion":                                                "aucune conversion",
+
+	// Errors, by the formats of their types
+	"API Error: %v":                           "Erreur d'API: %v",
+	"Rate Limit Error: %v":                    "Erreur de limite de requêtes: %v",
+	"JSON Error: %v":                          "Erreur JSON: %v",
+	"API request failed after %d retries":     "échec de la requête à l'API après %d tentatives",
+	"API request failed after %d retries: %v": "échec de la requête à l'API après %d tentatives: %v",
+	"unknown unit: %s":                        "unité inconnue: %s",
+	"%s (%s) is not a %s unit":                "%s (%s) n'est pas une unité de %s",
+	"cannot convert %s (%s) to %s (%s)":       "impossible de convertir %s (%s) en %s (%s)",
+
+	// Dimensions and units
+	"temperature":           "température",
+	"speed":                 "vitesse",
+	"length":                "longueur",
+	"pressure":              "pression",
+	"precipitation rate":    "intensité des précipitations",
+	"degree Celsius":        "degré Celsius",
+	"degree Fahrenheit":     "degré Fahrenheit",
+	"kelvin":                "kelvin",
+	"metre per second":      "mètre par seconde",
+	"kilometre per hour":    "kilomètre par heure",
+	"mile per hour":         "mille par heure",
+	"knot":                  "nœud",
+	"millimetre":            "millimètre",
+	"centimetre":            "centimètre",
+	"metre":                 "mètre",
+	"kilometre":             "kilomètre",
+	"inch":                  "pouce",
+	"foot":                  "pied",
+	"mile":                  "mille",
+	"hectopascal":           "hectopascal",
+	"pascal":                "pascal",
+	"kilopascal":            "kilopascal",
+	"inch of mercury":       "pouce de mercure",
+	"millimetre of mercury": "millimètre de mercure",
+	"millimetre per hour":   "millimètre par heure",
+	"inch per hour":         "pouce par heure",
+
+	// Wind
+	"calm":            "calme",
+	"light air":       "très légère brise",
+	"light breeze":    "légère brise",
+	"gentle breeze":   "petite brise",
+	"moderate breeze": "jolie brise",
+	"fresh breeze":    "bonne brise",
+	"strong breeze":   "vent frais",
+	"near gale":       "grand frais",
+	"gale":            "coup de vent",
+	"strong gale":     "fort coup de vent",
+	"storm":           "tempête",
+	"violent storm":   "violente tempête",
+	"hurricane force": "ouragan",
+	"sustained":       "soutenu",
+	"gusty":           "en rafales",
+
+	// Risk levels, intensity classes and alert severities
+	"none":      "aucun",
+	"low":       "faible",
+	"moderate":  "modéré",
+	"high":      "élevé",
+	"very high": "très élevé",
+	"extreme":   "extrême",
+	"light":     "faible",
+	"heavy":     "fort",
+	"advisory":  "avis",
+	"watch":     "veille",
+	"warning":   "avertissement",
+	"unknown":   "inconnu",
+
+	// Extreme events
+	"heat wave": "canicule",
+	"cold snap": "vague de froid",
+	"freeze":    "gel",
+	"high wind": "vents violents",
+
+	// Pressure tendency
+	"steady":               "stable",
+	"rising slowly":        "en hausse lente",
+	"rising":               "en hausse",
+	"rising quickly":       "en hausse rapide",
+	"rising very rapidly":  "en hausse très rapide",
+	"falling slowly":       "en baisse lente",
+	"falling":              "en baisse",
+	"falling quickly":      "en baisse rapide",
+	"falling very rapidly": "en baisse très rapide",
+
+	// Sun and moon
+	"night":                 "nuit",
+	"astronomical twilight": "crépuscule astronomique",
+	"nautical twilight":     "crépuscule nautique",
+	"civil twilight":        "crépuscule civil",
+	"daylight":              "jour",
+	"new moon":              "nouvelle lune",
+	"waxing crescent":       "premier croissant",
+	"first quarter":         "premier quartier",
+	"waxing gibbous":        "gibbeuse croissante",
+	"full moon":             "pleine lune",
+	"waning gibbous":        "gibbeuse décroissante",
+	"last quarter":          "dernier quartier",
+	"waning crescent":       "dernier croissant",
+
+	// Conditions
+	"rain":                "pluie",
+	"snow":                "neige",
+	"sleet":               "grésil",
+	"precipitation":       "précipitations",
+	"light rain":          "pluie faible",
+	"heavy rain":          "forte pluie",
+	"light snow":          "neige faible",
+	"heavy snow":          "forte neige",
+	"light sleet":         "grésil faible",
+	"heavy sleet":         "fort grésil",
+	"light precipitation": "précipitations faibles",
+	"heavy precipitation": "fortes précipitations",
+	"foggy":               "brouillard",
+	"clear":               "ciel dégagé",
+	"partly cloudy":       "partiellement nuageux",
+	"mostly cloudy":       "plutôt nuageux",
+	"overcast":            "couvert",
+	"windy and %s":        "venteux et %s",
+
+	// Times
+	"today":              "aujourd'hui",
+	"tomorrow":           "demain",
+	"on %s":              "%s",
+	"this morning":       "ce matin",
+	"this afternoon":     "cet après-midi",
+	"this evening":       "ce soir",
+	"tonight":            "cette nuit",
+	"overnight":          "pendant la nuit",
+	"tomorrow morning":   "demain matin",
+	"tomorrow afternoon": "demain après-midi",
+	"tomorrow evening":   "demain soir",
+	"tomorrow night":     "demain dans la nuit",
+	"%s morning":         "%s matin",
+	"%s afternoon":       "%s après-midi",
+	"%s evening":         "%s soir",
+	"%s night":           "%s dans la nuit",
+	"in the morning":     "dans la matinée",
+	"in the afternoon":   "dans l'après-midi",
+	"in the evening":     "en soirée",
+	"in %d min":          "dans %d min",
+	" and ":              " et ",
+})
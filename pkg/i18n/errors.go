@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+)
+
+// Localizer is implemented by errors that translate their own message
+type Localizer interface {
+	Localize(locale string) string
+}
+
+// MessageFormatter is implemented by errors whose message is a catalog format with arguments, such
+// as the SDK's *pirateweather.RetryError. Arguments that are errors or catalog messages are translated too.
+type MessageFormatter interface {
+	MessageFormat() (format string, args []any)
+}
+
+// Error translates the message of an error into the locale. Errors implementing Localizer translate
+// themselves. An error whose text is a catalog message translates as that message, so sentinels such
+// as models.ErrNoData only need a catalog entry, and errors implementing MessageFormatter translate
+// their format. Other errors keep their text, with the errors they wrap translated in place, so
+// fmt.Errorf("%w: 2024-05-03", models.ErrNoData) translates the sentinel and keeps the date.
+// Errors without catalog messages, such as those of the standard library, stay in English.
+func Error(locale string, err error) string {
+	if err == nil {
+		return ""
+	}
+	var localizer Localizer
+	if errors.As(err, &localizer) {
+		return localizer.Localize(locale)
+	}
+	return translateError(locale, err)
+}
+
+func translateError(locale string, err error) string {
+	text := err.Error()
+	if translation, ok := Lookup(locale, text); ok {
+		return translation
+	}
+	if formatter, ok := err.(MessageFormatter); ok {
+		format, args := formatter.MessageFormat()
+		translated := make([]any, len(args))
+		for i, arg := range args {
+			switch a := arg.(type) {
+			case error:
+				translated[i] = translateError(locale, a)
+			case string:
+				translated[i] = Translate(locale, a)
+			default:
+				translated[i] = arg
+			}
+		}
+		return Sprintf(locale, format, translated...)
+	}
+
+	var wrapped []error
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		wrapped = []error{e.Unwrap()}
+	case interface{ Unwrap() []error }:
+		wrapped = e.Unwrap()
+	}
+	var b strings.Builder
+	for _, w := range wrapped {
+		if w == nil {
+			continue
+		}
+		inner := w.Error()
+		i := strings.Index(text, inner)
+		if i < 0 {
+			continue
+		}
+		b.WriteString(text[:i])
+		b.WriteString(translateError(locale, w))
+		text = text[i+len(inner):]
+	}
+	b.WriteString(text)
+	return b.String()
+}
@@ -0,0 +1,109 @@
+// Package i18n translates the text the SDK produces itself: error messages, compass points, unit
+// and dimension names, and descriptions such as Beaufort forces, risk levels and moon phases.
+// Messages are identified by their English text, as in gettext, so a message without a
+// translation reads in English. Errors are translated by their message too, so a sentinel only needs a
+// catalog entry, and the SDK's error types by their format and arguments.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/utils"
+)
+
+// Catalog maps messages to their translation. A message may be a fmt format such as
+// "API request failed after %d retries"; the translation then takes the same verbs in the same order.
+type Catalog map[string]string
+
+var (
+	mu       sync.RWMutex
+	catalogs = make(map[string]Catalog)
+)
+
+func init() {
+	Register("de", german)
+	Register("es", spanish)
+	Register("fr", french)
+}
+
+// normalize turns "pt_BR" and "PT-br" into "pt-br"
+func normalize(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(locale), "_", "-")
+}
+
+// Register adds messages to the catalog of a locale such as "it" or "pt-BR", replacing existing translations.
+// A regional locale falls back to its language, so "pt-BR" only needs the messages that differ from "pt".
+func Register(locale string, messages Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	locale = normalize(locale)
+	c, ok := catalogs[locale]
+	if !ok {
+		c = make(Catalog)
+		catalogs[locale] = c
+	}
+	for message, translation := range messages {
+		c[message] = translation
+	}
+}
+
+// Locales returns the registered locales in alphabetical order
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Lookup returns the translation of a message into the locale, or the regional locale's language,
+// and whether there is one
+func Lookup(locale, message string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	locale = normalize(locale)
+	for {
+		if translation, ok := catalogs[locale][message]; ok {
+			return translation, true
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return "", false
+		}
+		locale = locale[:i]
+	}
+}
+
+// Translate returns the translation of a message into the locale, or the message itself when
+// the locale or the message is unknown. The empty locale and "en" are English.
+func Translate(locale, message string) string {
+	if translation, ok := Lookup(locale, message); ok {
+		return translation
+	}
+	return message
+}
+
+// Sprintf formats the translation of format into the locale with the arguments
+func Sprintf(locale, format string, args ...any) string {
+	return fmt.Sprintf(Translate(locale, format), args...)
+}
+
+// Date formats t with a time.Format layout in t's location, translating month and weekday names into the locale
+func Date(locale string, t time.Time, layout string) string {
+	return utils.FormatTime(t.Unix(), utils.WithLayout(layout), utils.WithLocation(t.Location()), utils.WithLocale(locale))
+}
+
+// Number formats a value with the given number of decimals and the separators of the locale, e.g. "1.234,5" in German
+func Number(locale string, value float64, decimals int) string {
+	return utils.FormatNumber(value, decimals, locale)
+}
@@ -0,0 +1,105 @@
+package i18n_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/i18n"
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweather"
+	"github.com/jdotcurs/pirateweather-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	require.Equal(t, "WSW", i18n.Translate("de", meteorology.Compass(250, meteorology.Compass16)))
+	require.Equal(t, "OzS", i18n.Translate("de", meteorology.Compass(101, meteorology.Compass32)))
+	require.Equal(t, "NO", i18n.Translate("fr", meteorology.Compass(315, meteorology.Compass8)))
+	require.Equal(t, "frische Brise", i18n.Translate("de-AT", meteorology.Beaufort(9).String()))
+	require.Equal(t, "muy alto", i18n.Translate("es_MX", meteorology.RiskVeryHigh.String()))
+	require.Equal(t, "pleine lune", i18n.Translate("fr", astronomy.PhaseName(0.5)))
+	require.Equal(t, "Warnung", i18n.Translate("de", models.SeverityWarning.String()))
+
+	// English, unknown locales and unknown messages fall back to the message
+	require.Equal(t, "full moon", i18n.Translate("", "full moon"))
+	require.Equal(t, "full moon", i18n.Translate("en-GB", "full moon"))
+	require.Equal(t, "full moon", i18n.Translate("ja", "full moon"))
+	require.Equal(t, "not in the catalog", i18n.Translate("fr", "not in the catalog"))
+
+	require.Equal(t, "windig und klar", i18n.Sprintf("de", "windy and %s", i18n.Translate("de", "clear")))
+}
+
+func TestRegister(t *testing.T) {
+	i18n.Register("pt", i18n.Catalog{"full moon": "lua cheia", "new moon": "lua nova"})
+	i18n.Register("pt-BR", i18n.Catalog{"new moon": "lua nova (BR)"})
+
+	require.Equal(t, "lua nova (BR)", i18n.Translate("pt_BR", "new moon"))
+	require.Equal(t, "lua cheia", i18n.Translate("pt-BR", "full moon"))
+	require.Equal(t, "lua nova", i18n.Translate("pt-PT", "new moon"))
+	require.Subset(t, i18n.Locales(), []string{"de", "es", "fr", "pt", "pt-br"})
+}
+
+type localized struct{}
+
+func (localized) Error() string                 { return "localized" }
+func (localized) Localize(locale string) string { return "localized in " + locale }
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		locale string
+		err    error
+		want   string
+	}{
+		{"fr", pirateweather.ErrBadRequest, "requête invalide: latitude ou longitude invalide"},
+		{"de", &pirateweather.RetryError{Retries: 3, Err: pirateweather.ErrServerError},
+			"API-Anfrage nach 3 Wiederholungen fehlgeschlagen: interner Serverfehler"},
+		{"es", &pirateweather.APIError{Message: "API request failed with status code: 502", Err: fmt.Errorf("%w: %d", pirateweather.ErrStatus, 502)},
+			"Error de la API: la solicitud a la API falló con el código de estado: 502"},
+		{"de", fmt.Errorf("%w: %w", pirateweather.ErrRequest, errors.New("dial tcp: connection refused")),
+			"Fehler beim Senden der Anfrage: dial tcp: connection refused"},
+		{"fr", fmt.Errorf("%w: 2024-05-03", models.ErrNoData), "aucune donnée pour l'heure demandée: 2024-05-03"},
+		{"es", fmt.Errorf("%w: %w", models.ErrNoDaily, meteorology.ErrMissingInput),
+			"el pronóstico no tiene bloque diario: faltan datos de entrada"},
+		{"en", models.ErrNoHourly, "forecast has no hourly block"},
+		{"de", fmt.Errorf("wrapped: %w", localized{}), "localized in de"},
+
+		// Errors are recognised by their text, whichever package they come from
+		{"fr", errors.New("bad request: invalid latitude or longitude"), "requête invalide: latitude ou longitude invalide"},
+		{"fr", &pirateweather.APIError{Message: "internal server error"}, "Erreur d'API: erreur interne du serveur"},
+		{"fr", &pirateweather.APIError{Message: "gateway timeout"}, "Erreur d'API: gateway timeout"},
+		{"es", &pirateweather.RetryError{Retries: 2}, "la solicitud a la API falló tras 2 reintentos"},
+		{"de", &pirateweather.RateLimitError{Message: "rate limit exceeded", Err: pirateweather.ErrRateLimited},
+			"Ratenlimit-Fehler: " + i18n.Translate("de", "rate limit exceeded")},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, i18n.Error(tt.locale, tt.err))
+	}
+
+	_, err := utils.ConvertUnit(1, "mm", "hPa")
+	require.Equal(t, "mm (Länge) lässt sich nicht in hPa (Druck) umrechnen", i18n.Error("de", err))
+	_, err = utils.ConvertUnit(1, "furlong", "m")
+	require.Equal(t, "unité inconnue: furlong", i18n.Error("fr", err))
+	require.Empty(t, i18n.Error("de", nil))
+
+	errFlooded := errors.New("road flooded")
+	i18n.Register("de", i18n.Catalog{"road flooded": "Straße überflutet"})
+	require.Equal(t, "Umleitung: Straße überflutet", i18n.Error("de", fmt.Errorf("Umleitung: %w", errFlooded)))
+}
+
+func TestFormatting(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	date := time.Date(2024, 5, 3, 14, 30, 0, 0, paris)
+
+	require.Equal(t, "vendredi 3 mai 2024, 14:30", i18n.Date("fr", date, "Monday 2 January 2006, 15:04"))
+	require.Equal(t, "Friday 3 May", i18n.Date("", date, "Monday 2 January"))
+
+	require.Equal(t, "1.234,6", i18n.Number("de", 1234.56, 1))
+	require.Equal(t, "-1\u202f234\u202f567", i18n.Number("fr-CA", -1234567, 0))
+	require.Equal(t, "1,234.56", i18n.Number("", 1234.56, 2))
+	require.Equal(t, "0,5", i18n.Number("es", 0.5, 1))
+}
@@ -0,0 +1,93 @@
+package icons
+
+import (
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Thresholds in SI units, matching the API's icon rules
+const (
+	precipitationThreshold = 0.1  // mm/h
+	fogVisibility          = 1.0  // km
+	windySpeed             = 10.0 // m/s
+	cloudyCover            = 0.75
+	partlyCloudyCover      = 0.375
+)
+
+// Daytime reports whether the sun is above the horizon at t, using the same sunrise elevation as the API's sun times
+func Daytime(t time.Time, latitude, longitude float64) bool {
+	return astronomy.SunPosition(t, latitude, longitude).Elevation > astronomy.SunriseElevation
+}
+
+// At returns the day or night variant of the condition for the sun at t
+func (c Condition) At(t time.Time, latitude, longitude float64) Condition {
+	return c.Variant(Daytime(t, latitude, longitude))
+}
+
+// Derive returns the condition of a data point whose values are in the given units system, following
+// the API's rules: precipitation of at least 0.1 mm/h, then fog below 1 km visibility, wind of at least
+// 10 m/s, and finally the cloud cover. Missing fields are skipped.
+func Derive(p *models.DataPoint, units string, daytime bool) (Condition, error) {
+	si := func(q models.Quantity, name string) (float64, bool, error) {
+		value, ok := p.Value(name)
+		if !ok {
+			return 0, false, nil
+		}
+		value, err := models.ConvertValue(q, value, units, models.UnitsSI)
+		return value, true, err
+	}
+
+	intensity, hasIntensity, err := si(models.QuantityPrecipIntensity, "precipIntensity")
+	if err != nil {
+		return Unknown, err
+	}
+	if hasIntensity && intensity >= precipitationThreshold && p.PrecipType != "none" {
+		if kind := Parse(p.PrecipType); kind.Precipitation() {
+			return kind, nil
+		}
+		return Rain, nil
+	}
+
+	if visibility, ok, err := si(models.QuantityDistance, "visibility"); err != nil {
+		return Unknown, err
+	} else if ok && visibility < fogVisibility {
+		return Fog, nil
+	}
+	if windSpeed, ok, err := si(models.QuantitySpeed, "windSpeed"); err != nil {
+		return Unknown, err
+	} else if ok && windSpeed >= windySpeed {
+		return Wind, nil
+	}
+
+	cloudCover, _ := p.Value("cloudCover")
+	switch {
+	case cloudCover > cloudyCover:
+		return Cloudy, nil
+	case cloudCover > partlyCloudyCover:
+		return PartlyCloudyDay.Variant(daytime), nil
+	default:
+		return ClearDay.Variant(daytime), nil
+	}
+}
+
+// Dominant returns the most frequent condition of the points, counting night icons as their day
+// variants. Ties go to the condition seen first, unless a later one is precipitation and it is not.
+// Points without a recognised icon are skipped; it returns Unknown if none has one.
+func Dominant(points []models.DataPoint) Condition {
+	counts := make(map[Condition]int)
+	best := Unknown
+	for _, p := range points {
+		condition := Parse(p.Icon).Variant(true)
+		if condition == Unknown {
+			continue
+		}
+		counts[condition]++
+		if best == Unknown || counts[condition] > counts[best] ||
+			(counts[condition] == counts[best] && condition.Precipitation() && !best.Precipitation()) {
+			best = condition
+		}
+	}
+	return best
+}
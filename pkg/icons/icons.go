@@ -0,0 +1,191 @@
+// Package icons parses the icon names of data points into typed conditions and maps them to
+// emoji, WMO and Open-Meteo weather codes and the names of common icon fonts.
+package icons
+
+import "strings"
+
+// Condition is the weather condition an icon stands for
+type Condition int
+
+const (
+	Unknown Condition = iota
+	ClearDay
+	ClearNight
+	PartlyCloudyDay
+	PartlyCloudyNight
+	Cloudy
+	Rain
+	Snow
+	Sleet
+	Wind
+	Fog
+	Hail
+	Thunderstorm
+)
+
+// icon holds the names and codes of a condition
+type icon struct {
+	name           string
+	emoji          string
+	wmo            int
+	openMeteo      int
+	weatherIcon    string
+	materialSymbol string
+}
+
+// icons is indexed by Condition. WMO codes are present weather codes from WMO code table 4677,
+// e.g. 45 fog, 63 rain, 68 rain and snow (sleet), 89 hail. In the table 0–3 describe the change in
+// the sky during the past hour; like most forecast APIs, the package reads them as cloud amount:
+// 0 clear, 2 partly cloudy, 3 overcast. Open-Meteo's weather interpretation codes reuse numbers
+// of the table with their own meanings and have no code for wind or sleet.
+var icons = []icon{
+	Unknown:           {"", "", -1, -1, "wi-na", "question_mark"},
+	ClearDay:          {"clear-day", "☀️", 0, 0, "wi-day-sunny", "clear_day"},
+	ClearNight:        {"clear-night", "🌙", 0, 0, "wi-night-clear", "bedtime"},
+	PartlyCloudyDay:   {"partly-cloudy-day", "⛅", 2, 2, "wi-day-cloudy", "partly_cloudy_day"},
+	PartlyCloudyNight: {"partly-cloudy-night", "☁️", 2, 2, "wi-night-alt-cloudy", "partly_cloudy_night"},
+	Cloudy:            {"cloudy", "☁️", 3, 3, "wi-cloudy", "cloud"},
+	Rain:              {"rain", "🌧️", 63, 63, "wi-rain", "rainy"},
+	Snow:              {"snow", "❄️", 73, 73, "wi-snow", "weather_snowy"},
+	Sleet:             {"sleet", "🌨️", 68, -1, "wi-sleet", "weather_mix"},
+	Wind:              {"wind", "💨", 18, -1, "wi-strong-wind", "air"},
+	Fog:               {"fog", "🌫️", 45, 45, "wi-fog", "foggy"},
+	Hail:              {"hail", "🧊", 89, 96, "wi-hail", "weather_hail"},
+	Thunderstorm:      {"thunderstorm", "⛈️", 95, 95, "wi-thunderstorm", "thunderstorm"},
+}
+
+func (c Condition) icon() icon {
+	if c < 0 || int(c) >= len(icons) {
+		return icons[Unknown]
+	}
+	return icons[c]
+}
+
+// Parse returns the condition of an icon name such as "partly-cloudy-night", or Unknown
+func Parse(name string) Condition {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for c, i := range icons {
+		if c != int(Unknown) && i.name == name {
+			return Condition(c)
+		}
+	}
+	return Unknown
+}
+
+// String returns the icon name used by the API, or "" for Unknown
+func (c Condition) String() string {
+	return c.icon().name
+}
+
+// Emoji returns an emoji for the condition, or "" for Unknown
+func (c Condition) Emoji() string {
+	return c.icon().emoji
+}
+
+// WMO returns the WMO present weather code (table 4677) of the condition, or -1 for Unknown
+func (c Condition) WMO() int {
+	return c.icon().wmo
+}
+
+// OpenMeteoCode returns Open-Meteo's weather code for the condition, or -1 for Unknown, Wind and Sleet
+func (c Condition) OpenMeteoCode() int {
+	return c.icon().openMeteo
+}
+
+// WeatherIcon returns the class name of the condition in the Weather Icons font, e.g. "wi-day-cloudy"
+func (c Condition) WeatherIcon() string {
+	return c.icon().weatherIcon
+}
+
+// MaterialSymbol returns the name of the condition in Google's Material Symbols, e.g. "partly_cloudy_day"
+func (c Condition) MaterialSymbol() string {
+	return c.icon().materialSymbol
+}
+
+// Precipitation reports whether the condition is a kind of precipitation
+func (c Condition) Precipitation() bool {
+	switch c {
+	case Rain, Snow, Sleet, Hail, Thunderstorm:
+		return true
+	}
+	return false
+}
+
+// Night reports whether the condition is a night variant
+func (c Condition) Night() bool {
+	return c == ClearNight || c == PartlyCloudyNight
+}
+
+// Variant returns the day or night variant of the condition. Conditions without variants are returned unchanged.
+func (c Condition) Variant(daytime bool) Condition {
+	switch {
+	case daytime && c == ClearNight:
+		return ClearDay
+	case daytime && c == PartlyCloudyNight:
+		return PartlyCloudyDay
+	case !daytime && c == ClearDay:
+		return ClearNight
+	case !daytime && c == PartlyCloudyDay:
+		return PartlyCloudyNight
+	}
+	return c
+}
+
+// FromWMO returns the condition of a WMO present weather code (table 4677), choosing the day or night
+// variant. Codes 0–3 are read as cloud amount; freezing drizzle and rain count as rain, rain and snow
+// as sleet, and snow pellets and hail as hail.
+func FromWMO(code int, daytime bool) Condition {
+	var c Condition
+	switch {
+	case code == 0 || code == 1:
+		c = ClearDay
+	case code == 2:
+		c = PartlyCloudyDay
+	case code == 3:
+		c = Cloudy
+	case code >= 4 && code <= 12 || code == 28 || code >= 40 && code <= 49:
+		c = Fog
+	case code == 17 || code == 29 || code >= 91 && code <= 99:
+		c = Thunderstorm
+	case code == 18 || code == 19 || code >= 30 && code <= 39:
+		c = Wind
+	case code == 27 || code >= 87 && code <= 90:
+		c = Hail
+	case code == 23 || code == 68 || code == 69 || code == 83 || code == 84:
+		c = Sleet
+	case code == 22 || code == 26 || code >= 70 && code <= 79 || code == 85 || code == 86:
+		c = Snow
+	case code >= 20 && code <= 25 || code >= 50 && code <= 67 || code >= 80 && code <= 82:
+		c = Rain
+	default:
+		return Unknown
+	}
+	return c.Variant(daytime)
+}
+
+// FromOpenMeteo returns the condition of an Open-Meteo weather code, choosing the day or night variant.
+// Freezing drizzle and rain count as rain, and thunderstorms with hail as hail.
+func FromOpenMeteo(code int, daytime bool) Condition {
+	var c Condition
+	switch code {
+	case 0, 1:
+		c = ClearDay
+	case 2:
+		c = PartlyCloudyDay
+	case 3:
+		c = Cloudy
+	case 45, 48:
+		c = Fog
+	case 51, 53, 55, 56, 57, 61, 63, 65, 66, 67, 80, 81, 82:
+		c = Rain
+	case 71, 73, 75, 77, 85, 86:
+		c = Snow
+	case 95:
+		c = Thunderstorm
+	case 96, 99:
+		c = Hail
+	default:
+		return Unknown
+	}
+	return c.Variant(daytime)
+}
@@ -0,0 +1,176 @@
+package icons_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/icons"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	c := icons.Parse("partly-cloudy-night")
+	require.Equal(t, icons.PartlyCloudyNight, c)
+	require.Equal(t, "partly-cloudy-night", c.String())
+	require.True(t, c.Night())
+	require.Equal(t, icons.PartlyCloudyDay, c.Variant(true))
+	require.Equal(t, "wi-night-alt-cloudy", c.WeatherIcon())
+	require.Equal(t, "partly_cloudy_night", c.MaterialSymbol())
+	require.Equal(t, 2, c.WMO())
+	require.Equal(t, 2, c.OpenMeteoCode())
+
+	require.Equal(t, icons.Rain, icons.Parse(" RAIN "))
+	require.True(t, icons.Parse("sleet").Precipitation())
+	require.False(t, icons.Parse("fog").Precipitation())
+	require.Equal(t, "🌧️", icons.Rain.Emoji())
+
+	unknown := icons.Parse("tornado")
+	require.Equal(t, icons.Unknown, unknown)
+	require.Equal(t, -1, unknown.WMO())
+	require.Equal(t, -1, unknown.OpenMeteoCode())
+	require.Equal(t, 18, icons.Wind.WMO())
+	require.Equal(t, -1, icons.Wind.OpenMeteoCode())
+	require.Equal(t, 68, icons.Sleet.WMO())
+	require.Equal(t, -1, icons.Sleet.OpenMeteoCode())
+	require.Equal(t, 89, icons.Hail.WMO())
+	require.Equal(t, 96, icons.Hail.OpenMeteoCode())
+	require.Empty(t, unknown.String())
+	require.Equal(t, icons.Wind, icons.Wind.Variant(false))
+}
+
+func TestFromWMO(t *testing.T) {
+	tests := []struct {
+		code    int
+		daytime bool
+		want    icons.Condition
+	}{
+		{0, true, icons.ClearDay},
+		{1, false, icons.ClearNight},
+		{2, false, icons.PartlyCloudyNight},
+		{3, true, icons.Cloudy},
+		{10, true, icons.Fog},
+		{45, true, icons.Fog},
+		{18, true, icons.Wind},
+		{38, true, icons.Wind},
+		{53, true, icons.Rain},
+		{57, true, icons.Rain}, // freezing drizzle
+		{63, true, icons.Rain},
+		{66, true, icons.Rain}, // freezing rain
+		{67, true, icons.Rain},
+		{68, true, icons.Sleet},
+		{69, true, icons.Sleet},
+		{84, true, icons.Sleet},
+		{73, true, icons.Snow},
+		{86, true, icons.Snow},
+		{89, true, icons.Hail},
+		{90, true, icons.Hail},
+		{95, true, icons.Thunderstorm},
+		{17, true, icons.Thunderstorm},
+		{100, true, icons.Unknown},
+		{-1, true, icons.Unknown},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, icons.FromWMO(tt.code, tt.daytime), "code %d", tt.code)
+	}
+
+	// Every known condition round-trips through its WMO code
+	for c := icons.ClearDay; c <= icons.Thunderstorm; c++ {
+		require.Equal(t, c, icons.FromWMO(c.WMO(), !c.Night()), c.String())
+	}
+}
+
+func TestFromOpenMeteo(t *testing.T) {
+	tests := []struct {
+		code    int
+		daytime bool
+		want    icons.Condition
+	}{
+		{0, true, icons.ClearDay},
+		{1, false, icons.ClearNight},
+		{2, false, icons.PartlyCloudyNight},
+		{3, false, icons.Cloudy},
+		{45, true, icons.Fog},
+		{48, true, icons.Fog},
+		{53, true, icons.Rain},
+		{82, true, icons.Rain},
+		{56, true, icons.Rain}, // freezing drizzle
+		{67, true, icons.Rain}, // freezing rain
+		{75, true, icons.Snow},
+		{86, true, icons.Snow},
+		{95, true, icons.Thunderstorm},
+		{99, true, icons.Hail},
+		// Codes of WMO table 4677 that Open-Meteo does not use
+		{18, true, icons.Unknown},
+		{68, true, icons.Unknown},
+		{89, true, icons.Unknown},
+		{120, true, icons.Unknown},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, icons.FromOpenMeteo(tt.code, tt.daytime), "code %d", tt.code)
+	}
+
+	// Every condition with a code round-trips through it
+	for c := icons.ClearDay; c <= icons.Thunderstorm; c++ {
+		if c.OpenMeteoCode() >= 0 {
+			require.Equal(t, c, icons.FromOpenMeteo(c.OpenMeteoCode(), !c.Night()), c.String())
+		}
+	}
+}
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		point   models.DataPoint
+		units   string
+		daytime bool
+		want    icons.Condition
+	}{
+		{models.DataPoint{PrecipIntensity: 0.5, PrecipType: "snow", Visibility: 10}, "si", true, icons.Snow},
+		{models.DataPoint{PrecipIntensity: 0.5, Visibility: 10}, "si", true, icons.Rain},
+		{models.DataPoint{PrecipIntensity: 0.003, PrecipType: "rain", Visibility: 10}, "us", true, icons.ClearDay},
+		{models.DataPoint{PrecipIntensity: 0.01, PrecipType: "rain", Visibility: 10}, "us", true, icons.Rain},
+		{models.DataPoint{Visibility: 0.4}, "us", true, icons.Fog},
+		{models.DataPoint{WindSpeed: 40, Visibility: 10}, "ca", true, icons.Wind},
+		{models.DataPoint{WindSpeed: 30, Visibility: 10}, "ca", true, icons.ClearDay},
+		{models.DataPoint{CloudCover: 0.8, Visibility: 10}, "si", false, icons.Cloudy},
+		{models.DataPoint{CloudCover: 0.5, Visibility: 10}, "si", false, icons.PartlyCloudyNight},
+		{models.DataPoint{CloudCover: 0.1, Visibility: 10}, "si", false, icons.ClearNight},
+	}
+	for i, tt := range tests {
+		got, err := icons.Derive(&tt.point, tt.units, tt.daytime)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "case %d", i)
+	}
+
+	// Missing visibility does not mean fog
+	p := models.DataPoint{CloudCover: 0.5}
+	p.SetAbsent("visibility")
+	got, err := icons.Derive(&p, "si", true)
+	require.NoError(t, err)
+	require.Equal(t, icons.PartlyCloudyDay, got)
+
+	_, err = icons.Derive(&p, "metric", true)
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestAt(t *testing.T) {
+	// Noon and midnight in Ottawa, 3 May 2024
+	noon := time.Date(2024, 5, 3, 16, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 5, 4, 4, 0, 0, 0, time.UTC)
+
+	require.Equal(t, icons.ClearDay, icons.ClearNight.At(noon, 45.42, -75.69))
+	require.Equal(t, icons.PartlyCloudyNight, icons.PartlyCloudyDay.At(midnight, 45.42, -75.69))
+	require.Equal(t, icons.Rain, icons.Rain.At(midnight, 45.42, -75.69))
+	require.True(t, icons.Daytime(noon, 45.42, -75.69))
+	require.False(t, icons.Daytime(midnight, 45.42, -75.69))
+}
+
+func TestDominant(t *testing.T) {
+	points := []models.DataPoint{{Icon: "clear-night"}, {Icon: "rain"}, {Icon: "clear-day"}, {Icon: "rain"}, {Icon: ""}, {Icon: "tornado"}}
+	// Two clear points (night counted as day) against two rain points: precipitation wins the tie
+	require.Equal(t, icons.Rain, icons.Dominant(points))
+	// Otherwise the first condition seen wins the tie
+	require.Equal(t, icons.Cloudy, icons.Dominant([]models.DataPoint{{Icon: "cloudy"}, {Icon: "fog"}}))
+	require.Equal(t, icons.ClearDay, icons.Dominant([]models.DataPoint{{Icon: "clear-night"}}))
+	require.Equal(t, icons.Unknown, icons.Dominant(nil))
+}
@@ -0,0 +1,121 @@
+package meteorology
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// ErrMissingInput is returned when a data point lacks a field an index needs
+var ErrMissingInput = errors.New("missing input")
+
+// Indices are the thermal comfort indices of a data point. Temperatures are in the temperature
+// unit of the point's units system; the risk categories are computed from the °C values.
+type Indices struct {
+	HeatIndex  float64
+	WindChill  float64
+	Humidex    float64
+	WetBulb    float64
+	WBGT       float64
+	FrostPoint float64
+
+	HeatIndexRisk Risk
+	WBGTRisk      Risk
+	HumidexRisk   Risk
+	WindChillRisk Risk
+}
+
+// HeatRisk returns the highest of the heat-related risks
+func (i Indices) HeatRisk() Risk {
+	return max(i.HeatIndexRisk, i.WBGTRisk, i.HumidexRisk)
+}
+
+// ThermalIndices computes the indices of a currently or hourly point in the given units system.
+// It needs the temperature and either the humidity or the dew point; a missing wind speed counts as calm.
+// Daily points have no instantaneous temperature and return ErrMissingInput.
+func ThermalIndices(p *models.DataPoint, units string) (Indices, error) {
+	toSI := func(q models.Quantity, name string) (float64, bool, error) {
+		value, ok := p.Value(name)
+		if !ok {
+			return 0, false, nil
+		}
+		value, err := models.ConvertValue(q, value, units, models.UnitsSI)
+		return value, true, err
+	}
+
+	temperature, ok, err := toSI(models.QuantityTemperature, "temperature")
+	if err != nil {
+		return Indices{}, err
+	}
+	if !ok {
+		return Indices{}, fmt.Errorf("%w: temperature", ErrMissingInput)
+	}
+	dewPoint, hasDewPoint, err := toSI(models.QuantityTemperature, "dewPoint")
+	if err != nil {
+		return Indices{}, err
+	}
+	humidity, hasHumidity := p.Value("humidity")
+	switch {
+	case !hasHumidity && !hasDewPoint:
+		return Indices{}, fmt.Errorf("%w: humidity and dewPoint", ErrMissingInput)
+	case !hasHumidity:
+		humidity = RelativeHumidity(temperature, dewPoint)
+	case !hasDewPoint:
+		dewPoint = DewPoint(temperature, humidity)
+	}
+	windSpeed, _, err := toSI(models.QuantitySpeed, "windSpeed")
+	if err != nil {
+		return Indices{}, err
+	}
+
+	si := Indices{
+		HeatIndex:  HeatIndex(temperature, humidity),
+		WindChill:  WindChill(temperature, windSpeed),
+		Humidex:    Humidex(temperature, dewPoint),
+		WetBulb:    WetBulb(temperature, humidity),
+		WBGT:       WBGT(temperature, humidity),
+		FrostPoint: FrostPoint(dewPoint),
+	}
+	si.HeatIndexRisk = HeatIndexRisk(si.HeatIndex)
+	si.WBGTRisk = WBGTRisk(si.WBGT)
+	si.HumidexRisk = HumidexRisk(si.Humidex)
+	si.WindChillRisk = WindChillRisk(si.WindChill)
+
+	converted := si
+	for _, value := range []*float64{&converted.HeatIndex, &converted.WindChill, &converted.Humidex,
+		&converted.WetBulb, &converted.WBGT, &converted.FrostPoint} {
+		if *value, err = models.ConvertValue(models.QuantityTemperature, *value, models.UnitsSI, units); err != nil {
+			return Indices{}, err
+		}
+	}
+	return converted, nil
+}
+
+// Annotation holds the indices of one data point of a block, or the reason they could not be computed
+type Annotation struct {
+	Time int64
+	Indices
+	Err error
+}
+
+// AnnotateBlock computes the indices of every point of the block
+func AnnotateBlock(block *models.DataBlock, units string) []Annotation {
+	if block == nil {
+		return nil
+	}
+	annotations := make([]Annotation, len(block.Data))
+	for i := range block.Data {
+		indices, err := ThermalIndices(&block.Data[i], units)
+		annotations[i] = Annotation{Time: block.Data[i].Time, Indices: indices, Err: err}
+	}
+	return annotations
+}
+
+// Annotate computes the indices of every hourly point of the forecast in its own units system
+func Annotate(forecast *models.ForecastResponse) ([]Annotation, error) {
+	if forecast.Hourly == nil {
+		return nil, models.ErrNoHourly
+	}
+	return AnnotateBlock(forecast.Hourly, forecast.Units()), nil
+}
@@ -0,0 +1,99 @@
+package meteorology
+
+// Risk is a heat or cold stress risk category, ordered from none to extreme
+type Risk int
+
+const (
+	RiskNone Risk = iota
+	RiskLow
+	RiskModerate
+	RiskHigh
+	RiskVeryHigh
+	RiskExtreme
+)
+
+func (r Risk) String() string {
+	switch r {
+	case RiskLow:
+		return "low"
+	case RiskModerate:
+		return "moderate"
+	case RiskHigh:
+		return "high"
+	case RiskVeryHigh:
+		return "very high"
+	case RiskExtreme:
+		return "extreme"
+	default:
+		return "none"
+	}
+}
+
+// HeatIndexRisk classifies a heat index in °C with the NWS categories: caution (low),
+// extreme caution (moderate), danger (high) and extreme danger (extreme)
+func HeatIndexRisk(heatIndex float64) Risk {
+	switch {
+	case heatIndex >= 51.7:
+		return RiskExtreme
+	case heatIndex >= 39.4:
+		return RiskHigh
+	case heatIndex >= 32.2:
+		return RiskModerate
+	case heatIndex >= 26.7:
+		return RiskLow
+	default:
+		return RiskNone
+	}
+}
+
+// WBGTRisk classifies a wet-bulb globe temperature in °C with the NWS WBGT risk levels, from
+// moderate at 26.7 °C (80 °F) to extreme above 32.2 °C (90 °F)
+func WBGTRisk(wbgt float64) Risk {
+	switch {
+	case wbgt > 32.2:
+		return RiskExtreme
+	case wbgt > 31.1:
+		return RiskVeryHigh
+	case wbgt > 29.4:
+		return RiskHigh
+	case wbgt > 26.7:
+		return RiskModerate
+	default:
+		return RiskNone
+	}
+}
+
+// HumidexRisk classifies a humidex with the Environment Canada ranges: some discomfort from 30,
+// great discomfort from 40, dangerous from 46 and heat stroke likely from 54
+func HumidexRisk(humidex float64) Risk {
+	switch {
+	case humidex >= 54:
+		return RiskExtreme
+	case humidex >= 46:
+		return RiskHigh
+	case humidex >= 40:
+		return RiskModerate
+	case humidex >= 30:
+		return RiskLow
+	default:
+		return RiskNone
+	}
+}
+
+// WindChillRisk classifies a wind chill in °C with the Environment Canada frostbite risk levels
+func WindChillRisk(windChill float64) Risk {
+	switch {
+	case windChill <= -55:
+		return RiskExtreme
+	case windChill <= -40:
+		return RiskVeryHigh
+	case windChill <= -28:
+		return RiskHigh
+	case windChill <= -10:
+		return RiskModerate
+	case windChill < 0:
+		return RiskLow
+	default:
+		return RiskNone
+	}
+}
@@ -0,0 +1,118 @@
+// Package meteorology derives quantities the API does not return, such as heat stress and
+// wind chill indices, Beaufort forces and compass directions, from data points.
+//
+// The formula functions work in SI units: temperatures in °C, wind speeds in m/s and relative
+// humidity as a fraction from 0 to 1, like the API's humidity field.
+package meteorology
+
+import "math"
+
+// HeatIndex returns the NWS heat index in °C. Below about 27 °C it is Steadman's simple estimate;
+// above, the Rothfusz regression with the NWS adjustments for very dry and very humid air.
+func HeatIndex(temperature, humidity float64) float64 {
+	f := celsiusToFahrenheit(temperature)
+	rh := humidity * 100
+
+	simple := 0.5 * (f + 61 + (f-68)*1.2 + rh*0.094)
+	if (simple+f)/2 < 80 {
+		return fahrenheitToCelsius(simple)
+	}
+
+	hi := -42.379 + 2.04901523*f + 10.14333127*rh - 0.22475541*f*rh - 0.00683783*f*f -
+		0.05481717*rh*rh + 0.00122874*f*f*rh + 0.00085282*f*rh*rh - 0.00000199*f*f*rh*rh
+	switch {
+	case rh < 13 && f >= 80 && f <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(f-95))/17)
+	case rh > 85 && f >= 80 && f <= 87:
+		hi += (rh - 85) / 10 * (87 - f) / 5
+	}
+	return fahrenheitToCelsius(hi)
+}
+
+// WindChill returns the wind chill in °C using the 2001 North American formula. It is only
+// defined at or below 10 °C with winds above 4.8 km/h; otherwise the air temperature is returned.
+func WindChill(temperature, windSpeed float64) float64 {
+	kmh := windSpeed * 3.6
+	if temperature > 10 || kmh <= 4.8 {
+		return temperature
+	}
+	v := math.Pow(kmh, 0.16)
+	return 13.12 + 0.6215*temperature - 11.37*v + 0.3965*temperature*v
+}
+
+// ApparentTemperature returns what the temperature feels like in °C: the heat index from 27 °C,
+// the wind chill at or below 10 °C, and the air temperature in between
+func ApparentTemperature(temperature, humidity, windSpeed float64) float64 {
+	switch {
+	case temperature >= 27:
+		return HeatIndex(temperature, humidity)
+	case temperature <= 10:
+		return WindChill(temperature, windSpeed)
+	default:
+		return temperature
+	}
+}
+
+// Humidex returns the Canadian humidex from the temperature and dew point in °C
+func Humidex(temperature, dewPoint float64) float64 {
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/(dewPoint+273.15)))
+	return temperature + 0.5555*(e-10)
+}
+
+// WetBulb returns the wet-bulb temperature in °C using Stull's (2011) empirical formula,
+// accurate to about 0.3 °C for humidities from 5% to 99% and temperatures from -20 to 50 °C
+func WetBulb(temperature, humidity float64) float64 {
+	rh := humidity * 100
+	return temperature*math.Atan(0.151977*math.Sqrt(rh+8.313659)) +
+		math.Atan(temperature+rh) - math.Atan(rh-1.676331) +
+		0.00391838*math.Pow(rh, 1.5)*math.Atan(0.023101*rh) - 4.686035
+}
+
+// WBGT estimates the wet-bulb globe temperature in °C with the Australian Bureau of Meteorology
+// approximation. It assumes moderate sunshine and light wind, so it is an estimate rather than a measurement.
+func WBGT(temperature, humidity float64) float64 {
+	return 0.567*temperature + 0.393*VapourPressure(temperature, humidity) + 3.94
+}
+
+// VapourPressure returns the water vapour pressure in hPa
+func VapourPressure(temperature, humidity float64) float64 {
+	return humidity * saturationVapourPressure(temperature)
+}
+
+// DewPoint returns the dew point in °C using the Magnus formula
+func DewPoint(temperature, humidity float64) float64 {
+	if humidity <= 0 {
+		return math.Inf(-1)
+	}
+	gamma := math.Log(humidity) + magnusA*temperature/(magnusB+temperature)
+	return magnusB * gamma / (magnusA - gamma)
+}
+
+// RelativeHumidity returns the relative humidity (0 to 1) from the temperature and dew point in °C using the Magnus formula
+func RelativeHumidity(temperature, dewPoint float64) float64 {
+	return math.Exp(magnusA*dewPoint/(magnusB+dewPoint)) / math.Exp(magnusA*temperature/(magnusB+temperature))
+}
+
+// FrostPoint returns the frost point in °C: the temperature at which the air's water vapour, given by
+// the dew point, saturates over ice. Below freezing it is slightly above the dew point.
+func FrostPoint(dewPoint float64) float64 {
+	const a, b = 22.46, 272.62 // Magnus coefficients over ice
+	x := math.Log(saturationVapourPressure(dewPoint) / 6.112)
+	return b * x / (a - x)
+}
+
+// Magnus coefficients over water
+const magnusA, magnusB = 17.625, 243.04
+
+// saturationVapourPressure returns the saturation vapour pressure over water in hPa
+func saturationVapourPressure(temperature float64) float64 {
+	return 6.112 * math.Exp(magnusA*temperature/(magnusB+temperature))
+}
+
+func celsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+func fahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
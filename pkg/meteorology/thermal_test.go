@@ -0,0 +1,130 @@
+package meteorology_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+func fahrenheit(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+func TestHeatIndex(t *testing.T) {
+	// Values from the NWS heat index table
+	require.InDelta(t, fahrenheit(95), meteorology.HeatIndex(fahrenheit(90), 0.5), 0.5)
+	require.InDelta(t, fahrenheit(121), meteorology.HeatIndex(fahrenheit(96), 0.65), 0.6)
+	require.InDelta(t, fahrenheit(84), meteorology.HeatIndex(fahrenheit(82), 0.55), 0.5)
+
+	// Below the Rothfusz range the simple formula stays close to the air temperature
+	require.InDelta(t, 20, meteorology.HeatIndex(20, 0.5), 1)
+}
+
+func TestWindChill(t *testing.T) {
+	// Environment Canada wind chill table: -20 °C at 30 km/h feels like -33
+	require.InDelta(t, -32.6, meteorology.WindChill(-20, 30/3.6), 0.1)
+	require.Equal(t, 15.0, meteorology.WindChill(15, 10))
+	require.Equal(t, -5.0, meteorology.WindChill(-5, 1))
+}
+
+func TestApparentTemperature(t *testing.T) {
+	require.Equal(t, 20.0, meteorology.ApparentTemperature(20, 0.9, 10))
+	require.Less(t, meteorology.ApparentTemperature(0, 0.8, 10), 0.0)
+	require.Greater(t, meteorology.ApparentTemperature(32, 0.7, 2), 32.0)
+}
+
+func TestHumidex(t *testing.T) {
+	require.InDelta(t, 34, meteorology.Humidex(30, 15), 0.1)
+	require.InDelta(t, 44.3, meteorology.Humidex(35, 22), 0.1)
+}
+
+func TestWetBulb(t *testing.T) {
+	// Stull (2011): 20 °C at 50% gives a wet-bulb temperature of 13.7 °C
+	require.InDelta(t, 13.7, meteorology.WetBulb(20, 0.5), 0.05)
+	require.InDelta(t, 30, meteorology.WetBulb(30, 0.99), 0.3)
+}
+
+func TestWBGT(t *testing.T) {
+	require.InDelta(t, 29.3, meteorology.WBGT(30, 0.5), 0.1)
+}
+
+func TestDewPointAndFrostPoint(t *testing.T) {
+	dewPoint := meteorology.DewPoint(25, 0.6)
+	require.InDelta(t, 16.7, dewPoint, 0.1)
+	require.InDelta(t, 0.6, meteorology.RelativeHumidity(25, dewPoint), 1e-9)
+
+	// Over ice the air saturates at a slightly higher temperature than the dew point
+	frostPoint := meteorology.FrostPoint(-12)
+	require.Greater(t, frostPoint, -12.0)
+	require.InDelta(t, -10.8, frostPoint, 0.2)
+	require.InDelta(t, 0, meteorology.FrostPoint(0), 0.05)
+}
+
+func TestRiskCategories(t *testing.T) {
+	require.Equal(t, meteorology.RiskNone, meteorology.HeatIndexRisk(25))
+	require.Equal(t, meteorology.RiskLow, meteorology.HeatIndexRisk(30))
+	require.Equal(t, meteorology.RiskModerate, meteorology.HeatIndexRisk(35))
+	require.Equal(t, meteorology.RiskHigh, meteorology.HeatIndexRisk(45))
+	require.Equal(t, meteorology.RiskExtreme, meteorology.HeatIndexRisk(55))
+
+	require.Equal(t, meteorology.RiskNone, meteorology.WBGTRisk(25))
+	require.Equal(t, meteorology.RiskModerate, meteorology.WBGTRisk(28))
+	require.Equal(t, meteorology.RiskVeryHigh, meteorology.WBGTRisk(31.5))
+	require.Equal(t, meteorology.RiskModerate, meteorology.HumidexRisk(42))
+	require.Equal(t, meteorology.RiskHigh, meteorology.WindChillRisk(-33))
+	require.Equal(t, meteorology.RiskNone, meteorology.WindChillRisk(5))
+	require.Equal(t, "very high", meteorology.RiskVeryHigh.String())
+}
+
+func TestThermalIndicesInAnyUnits(t *testing.T) {
+	point := models.DataPoint{Temperature: 90, Humidity: 0.5, WindSpeed: 5}
+	point.DewPoint = 69.6
+
+	indices, err := meteorology.ThermalIndices(&point, "us")
+	require.NoError(t, err)
+	require.InDelta(t, 95, indices.HeatIndex, 1)
+	require.Equal(t, 90.0, indices.WindChill)
+	require.Equal(t, meteorology.RiskModerate, indices.HeatIndexRisk)
+	require.Equal(t, meteorology.RiskVeryHigh, indices.WBGTRisk)
+	require.Equal(t, meteorology.RiskVeryHigh, indices.HeatRisk())
+
+	si := models.DataPoint{Temperature: fahrenheit(90), Humidity: 0.5, DewPoint: fahrenheit(69.6)}
+	siIndices, err := meteorology.ThermalIndices(&si, "si")
+	require.NoError(t, err)
+	require.InDelta(t, fahrenheit(indices.HeatIndex), siIndices.HeatIndex, 1e-6)
+	require.InDelta(t, fahrenheit(indices.WetBulb), siIndices.WetBulb, 1e-6)
+
+	_, err = meteorology.ThermalIndices(&si, "metric")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestAnnotate(t *testing.T) {
+	forecast := synthetic.Generate(33.45, -112.07, time.Date(2024, 7, 15, 6, 0, 0, 0, time.UTC),
+		synthetic.WithUnits("us"), synthetic.WithTimezone("America/Phoenix"))
+
+	annotations, err := meteorology.Annotate(forecast)
+	require.NoError(t, err)
+	require.Len(t, annotations, len(forecast.Hourly.Data))
+	for i, annotation := range annotations {
+		require.NoError(t, annotation.Err)
+		require.Equal(t, forecast.Hourly.Data[i].Time, annotation.Time)
+		require.Less(t, annotation.WetBulb, forecast.Hourly.Data[i].Temperature+0.01)
+	}
+
+	// Forecasts fetched without flags are in us units
+	forecast.Flags = nil
+	unflagged, err := meteorology.Annotate(forecast)
+	require.NoError(t, err)
+	require.Equal(t, annotations, unflagged)
+
+	// Daily points have no instantaneous temperature
+	daily := meteorology.AnnotateBlock(forecast.Daily, "us")
+	require.ErrorIs(t, daily[0].Err, meteorology.ErrMissingInput)
+
+	_, err = meteorology.Annotate(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoHourly)
+}
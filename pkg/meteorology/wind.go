@@ -0,0 +1,211 @@
+package meteorology
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// CompassRose is the number of points a bearing is named with
+type CompassRose int
+
+const (
+	Compass8  CompassRose = 8
+	Compass16 CompassRose = 16
+	Compass32 CompassRose = 32
+)
+
+var compass32 = []string{
+	"N", "NbE", "NNE", "NEbN", "NE", "NEbE", "ENE", "EbN",
+	"E", "EbS", "ESE", "SEbE", "SE", "SEbS", "SSE", "SbE",
+	"S", "SbW", "SSW", "SWbS", "SW", "SWbW", "WSW", "WbS",
+	"W", "WbN", "WNW", "NWbW", "NW", "NWbN", "NNW", "NbW",
+}
+
+// Compass returns the compass point of a bearing in degrees, such as "NE" on an 8-point rose,
+// "NNE" on a 16-point rose or "NbE" on a 32-point rose. Other roses are treated as 16-point.
+func Compass(bearing float64, rose CompassRose) string {
+	if rose != Compass8 && rose != Compass32 {
+		rose = Compass16
+	}
+	sector := 360 / float64(rose)
+	index := int(math.Floor(normalizeBearing(bearing)/sector+0.5)) % int(rose)
+	return compass32[index*32/int(rose)]
+}
+
+// BeaufortForce is a wind force on the Beaufort scale from 0 (calm) to 12 (hurricane force)
+type BeaufortForce int
+
+// beaufortLimits are the upper wind speeds in m/s of forces 0 to 11
+var beaufortLimits = []float64{0.5, 1.6, 3.4, 5.5, 8.0, 10.8, 13.9, 17.2, 20.8, 24.5, 28.5, 32.7}
+
+var beaufortDescriptions = []string{
+	"calm", "light air", "light breeze", "gentle breeze", "moderate breeze", "fresh breeze", "strong breeze",
+	"near gale", "gale", "strong gale", "storm", "violent storm", "hurricane force",
+}
+
+// Beaufort returns the Beaufort force of a wind speed in m/s
+func Beaufort(windSpeed float64) BeaufortForce {
+	for force, limit := range beaufortLimits {
+		if windSpeed < limit {
+			return BeaufortForce(force)
+		}
+	}
+	return 12
+}
+
+// BeaufortIn returns the Beaufort force of a wind speed in the wind speed unit of a units system
+func BeaufortIn(windSpeed float64, units string) (BeaufortForce, error) {
+	si, err := models.ConvertValue(models.QuantitySpeed, windSpeed, units, models.UnitsSI)
+	if err != nil {
+		return 0, err
+	}
+	return Beaufort(si), nil
+}
+
+// String returns the description of the force, such as "fresh breeze"
+func (f BeaufortForce) String() string {
+	if f < 0 || int(f) >= len(beaufortDescriptions) {
+		return fmt.Sprintf("BeaufortForce(%d)", int(f))
+	}
+	return beaufortDescriptions[f]
+}
+
+// GustFactor returns the ratio of the gust to the sustained wind speed in any speed unit, or 0 when the wind is calm
+func GustFactor(windSpeed, windGust float64) float64 {
+	if windSpeed <= 0 {
+		return 0
+	}
+	return windGust / windSpeed
+}
+
+// WindCharacter says whether the wind is calm, sustained or gusty
+type WindCharacter int
+
+const (
+	WindCalm WindCharacter = iota
+	WindSustained
+	WindGusty
+)
+
+func (c WindCharacter) String() string {
+	switch c {
+	case WindSustained:
+		return "sustained"
+	case WindGusty:
+		return "gusty"
+	default:
+		return "calm"
+	}
+}
+
+// ClassifyWind classifies a wind speed and gust in m/s. Following the METAR reporting rule, the wind
+// is gusty when the gusts exceed the sustained speed by 10 knots (5.1 m/s) or more.
+func ClassifyWind(windSpeed, windGust float64) WindCharacter {
+	switch {
+	case windSpeed < beaufortLimits[0] && windGust < beaufortLimits[0]:
+		return WindCalm
+	case windGust-windSpeed >= 5.1:
+		return WindGusty
+	default:
+		return WindSustained
+	}
+}
+
+// Wind describes the wind of a data point. Speeds are in the wind speed unit of the point's units system.
+type Wind struct {
+	Speed      float64
+	Gust       float64
+	Bearing    float64
+	Direction  string // 16-point compass direction the wind blows from, empty when the bearing is missing
+	Force      BeaufortForce
+	GustFactor float64
+	Character  WindCharacter
+}
+
+// WindConditions describes the wind of a data point in the given units system. It needs the wind speed;
+// a missing gust counts as the sustained speed.
+func WindConditions(p *models.DataPoint, units string) (Wind, error) {
+	speed, ok := p.Value("windSpeed")
+	if !ok {
+		return Wind{}, fmt.Errorf("%w: windSpeed", ErrMissingInput)
+	}
+	gust, ok := p.Value("windGust")
+	if !ok {
+		gust = speed
+	}
+	siSpeed, err := models.ConvertValue(models.QuantitySpeed, speed, units, models.UnitsSI)
+	if err != nil {
+		return Wind{}, err
+	}
+	siGust, err := models.ConvertValue(models.QuantitySpeed, gust, units, models.UnitsSI)
+	if err != nil {
+		return Wind{}, err
+	}
+
+	wind := Wind{
+		Speed:      speed,
+		Gust:       gust,
+		Force:      Beaufort(siSpeed),
+		GustFactor: GustFactor(speed, gust),
+		Character:  ClassifyWind(siSpeed, siGust),
+	}
+	if bearing, ok := p.Value("windBearing"); ok {
+		wind.Bearing = bearing
+		wind.Direction = Compass(bearing, Compass16)
+	}
+	return wind, nil
+}
+
+// MeanBearing returns the vector mean of the points' wind bearings, each weighted equally
+func MeanBearing(points []models.DataPoint) (float64, bool) {
+	var u, v float64
+	count := 0
+	for _, p := range points {
+		if bearing, ok := p.Value("windBearing"); ok {
+			u += math.Sin(bearing * math.Pi / 180)
+			v += math.Cos(bearing * math.Pi / 180)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return normalizeBearing(math.Atan2(u, v) * 180 / math.Pi), true
+}
+
+// MeanWind returns the vector mean wind of the points: each wind is weighted by its speed, so light
+// variable winds count for less than a strong steady one. The speed is the magnitude of the mean
+// vector, in the points' speed unit, and is lower than the scalar mean when the direction varies.
+func MeanWind(points []models.DataPoint) (speed, bearing float64, ok bool) {
+	var u, v float64
+	count := 0
+	for _, p := range points {
+		s, hasSpeed := p.Value("windSpeed")
+		b, hasBearing := p.Value("windBearing")
+		if !hasSpeed || !hasBearing {
+			continue
+		}
+		u += s * math.Sin(b*math.Pi/180)
+		v += s * math.Cos(b*math.Pi/180)
+		count++
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+	u, v = u/float64(count), v/float64(count)
+	return math.Hypot(u, v), normalizeBearing(math.Atan2(u, v) * 180 / math.Pi), true
+}
+
+// normalizeBearing maps a bearing in degrees to [0, 360)
+func normalizeBearing(bearing float64) float64 {
+	bearing = math.Mod(bearing, 360)
+	if bearing < 0 {
+		bearing += 360
+	}
+	if bearing >= 360 { // a tiny negative bearing rounds up to 360
+		return 0
+	}
+	return bearing
+}
@@ -0,0 +1,108 @@
+package meteorology_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompass(t *testing.T) {
+	tests := []struct {
+		bearing float64
+		rose    meteorology.CompassRose
+		want    string
+	}{
+		{0, meteorology.Compass8, "N"},
+		{22, meteorology.Compass8, "N"},
+		{23, meteorology.Compass8, "NE"},
+		{359, meteorology.Compass8, "N"},
+		{22.5, meteorology.Compass16, "NNE"},
+		{200, meteorology.Compass16, "SSW"},
+		{-90, meteorology.Compass16, "W"},
+		{720 + 45, meteorology.Compass16, "NE"},
+		{11.25, meteorology.Compass32, "NbE"},
+		{191, meteorology.Compass32, "SbW"},
+		{315, meteorology.Compass32, "NW"},
+		{67.5, meteorology.CompassRose(12), "ENE"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, meteorology.Compass(tt.bearing, tt.rose), "%v° on %d points", tt.bearing, tt.rose)
+	}
+}
+
+func TestBeaufort(t *testing.T) {
+	require.Equal(t, meteorology.BeaufortForce(0), meteorology.Beaufort(0.2))
+	require.Equal(t, meteorology.BeaufortForce(4), meteorology.Beaufort(5.5))
+	require.Equal(t, meteorology.BeaufortForce(8), meteorology.Beaufort(19))
+	require.Equal(t, meteorology.BeaufortForce(12), meteorology.Beaufort(40))
+	require.Equal(t, "fresh breeze", meteorology.Beaufort(9).String())
+	require.Equal(t, "BeaufortForce(13)", meteorology.BeaufortForce(13).String())
+
+	// 25 mph, 40 km/h and 11.2 m/s are all force 6
+	for units, speed := range map[string]float64{"us": 25, "uk": 25, "ca": 40, "si": 11.2} {
+		force, err := meteorology.BeaufortIn(speed, units)
+		require.NoError(t, err)
+		require.Equal(t, meteorology.BeaufortForce(6), force, units)
+	}
+	_, err := meteorology.BeaufortIn(10, "knots")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestGustsAndClassification(t *testing.T) {
+	require.InDelta(t, 1.5, meteorology.GustFactor(10, 15), 1e-9)
+	require.Zero(t, meteorology.GustFactor(0, 3))
+
+	require.Equal(t, meteorology.WindCalm, meteorology.ClassifyWind(0.2, 0.4))
+	require.Equal(t, meteorology.WindSustained, meteorology.ClassifyWind(8, 11))
+	require.Equal(t, meteorology.WindGusty, meteorology.ClassifyWind(8, 14))
+	require.Equal(t, "gusty", meteorology.WindGusty.String())
+}
+
+func TestWindConditions(t *testing.T) {
+	point := models.DataPoint{WindSpeed: 15, WindGust: 30, WindBearing: 250}
+	wind, err := meteorology.WindConditions(&point, "us")
+	require.NoError(t, err)
+	require.Equal(t, "WSW", wind.Direction)
+	require.Equal(t, meteorology.BeaufortForce(4), wind.Force)
+	require.InDelta(t, 2, wind.GustFactor, 1e-9)
+	require.Equal(t, meteorology.WindGusty, wind.Character)
+
+	// The same gust spread in km/h is under 10 knots
+	wind, err = meteorology.WindConditions(&point, "ca")
+	require.NoError(t, err)
+	require.Equal(t, meteorology.BeaufortForce(3), wind.Force)
+	require.Equal(t, meteorology.WindSustained, wind.Character)
+
+	point.SetAbsent("windGust", "windBearing")
+	wind, err = meteorology.WindConditions(&point, "si")
+	require.NoError(t, err)
+	require.Equal(t, 15.0, wind.Gust)
+	require.Empty(t, wind.Direction)
+
+	point.SetAbsent("windSpeed")
+	_, err = meteorology.WindConditions(&point, "si")
+	require.ErrorIs(t, err, meteorology.ErrMissingInput)
+}
+
+func TestMeanWind(t *testing.T) {
+	points := []models.DataPoint{
+		{WindSpeed: 10, WindBearing: 350},
+		{WindSpeed: 10, WindBearing: 10},
+		{WindSpeed: 2, WindBearing: 180},
+	}
+
+	bearing, ok := meteorology.MeanBearing(points[:2])
+	require.True(t, ok)
+	require.InDelta(t, 0, math.Remainder(bearing, 360), 1e-9)
+
+	speed, bearing, ok := meteorology.MeanWind(points)
+	require.True(t, ok)
+	require.InDelta(t, 0, math.Remainder(bearing, 360), 1e-9)
+	require.InDelta(t, (20*0.98481-2)/3, speed, 1e-4)
+
+	_, _, ok = meteorology.MeanWind(nil)
+	require.False(t, ok)
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity is the severity of a weather alert, ordered from least to most severe
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityAdvisory
+	SeverityWatch
+	SeverityWarning
+)
+
+// ParseSeverity parses a severity case-insensitively. Besides advisory, watch and warning it accepts the
+// CAP severities sent for some regions: minor maps to advisory, moderate to watch, and severe and extreme to warning.
+func ParseSeverity(s string) Severity {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "advisory", "minor":
+		return SeverityAdvisory
+	case "watch", "moderate":
+		return SeverityWatch
+	case "warning", "severe", "extreme":
+		return SeverityWarning
+	default:
+		return SeverityUnknown
+	}
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityAdvisory:
+		return "advisory"
+	case SeverityWatch:
+		return "watch"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalJSON parses the severity string; unrecognised values and null become SeverityUnknown.
+// Alert keeps the text of an unrecognised severity.
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var text *string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid severity %s: %w", data, err)
+	}
+	*s = SeverityUnknown
+	if text != nil {
+		*s = ParseSeverity(*text)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the severity as its lowercase name
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// SeverityText returns the severity as the API sent it if the SDK does not recognise it, or else its name
+func (a *Alert) SeverityText() string {
+	if a.Severity == SeverityUnknown && a.severityText != "" {
+		return a.severityText
+	}
+	return a.Severity.String()
+}
+
+// alertJSON has the fields of Alert without its JSON methods
+type alertJSON Alert
+
+// UnmarshalJSON decodes an alert, keeping the text of a severity the SDK does not recognise
+func (a *Alert) UnmarshalJSON(data []byte) error {
+	var alert alertJSON
+	if err := json.Unmarshal(data, &alert); err != nil {
+		return err
+	}
+	*a = Alert(alert)
+	a.severityText = ""
+	if a.Severity != SeverityUnknown {
+		return nil
+	}
+
+	var raw struct {
+		Severity *string `json:"severity"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Severity != nil {
+		a.severityText = *raw.Severity
+	}
+	return nil
+}
+
+// MarshalJSON encodes an alert, writing back the text of a severity the SDK does not recognise
+func (a Alert) MarshalJSON() ([]byte, error) {
+	if a.Severity != SeverityUnknown || a.severityText == "" {
+		return json.Marshal(alertJSON(a))
+	}
+	return json.Marshal(struct {
+		alertJSON
+		Severity string `json:"severity"`
+	}{alertJSON(a), a.severityText})
+}
+
+// IsActive reports whether the alert is in effect at t: it has been issued and has not yet expired.
+// An alert without an expiry time stays active.
+func (a *Alert) IsActive(t time.Time) bool {
+	if !a.Time.IsZero() && t.Before(a.Time.Time) {
+		return false
+	}
+	return a.Expires.IsZero() || t.Before(a.Expires.Time)
+}
+
+// IsExpired reports whether the alert has expired at t
+func (a *Alert) IsExpired(t time.Time) bool {
+	return !a.Expires.IsZero() && !t.Before(a.Expires.Time)
+}
+
+// ActiveAlerts returns the alerts in effect at t
+func (f *ForecastResponse) ActiveAlerts(t time.Time) []Alert {
+	var active []Alert
+	for i := range f.Alerts {
+		if f.Alerts[i].IsActive(t) {
+			active = append(active, f.Alerts[i])
+		}
+	}
+	return active
+}
+
+// AlertsAtLeast returns the alerts whose severity is at least the given one
+func (f *ForecastResponse) AlertsAtLeast(severity Severity) []Alert {
+	var alerts []Alert
+	for _, alert := range f.Alerts {
+		if alert.Severity >= severity {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
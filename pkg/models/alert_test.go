@@ -0,0 +1,138 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertDecodesEpochTimestamps(t *testing.T) {
+	body := `{
+		"latitude": 45.42,
+		"longitude": -75.69,
+		"alerts": [{
+			"title": "Winter Storm Warning",
+			"regions": ["Ottawa"],
+			"severity": "Warning",
+			"time": 1700000000,
+			"expires": 1700086400,
+			"description": "Heavy snow",
+			"uri": "https://example.com"
+		}]
+	}`
+
+	var forecast models.ForecastResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &forecast))
+	require.Len(t, forecast.Alerts, 1)
+
+	alert := forecast.Alerts[0]
+	require.Equal(t, models.SeverityWarning, alert.Severity)
+	require.Equal(t, int64(1700000000), alert.Time.Unix())
+	require.Equal(t, int64(1700086400), alert.Expires.Unix())
+}
+
+func TestTimestampFormats(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		zero     bool
+	}{
+		{`1700000000`, 1700000000, false},
+		{`"1700000000"`, 1700000000, false},
+		{`"2023-11-14T22:13:20Z"`, 1700000000, false},
+		{`"2023-11-14T17:13:20-05:00"`, 1700000000, false},
+		{`null`, 0, true},
+		{`""`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var ts models.Timestamp
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &ts))
+			require.Equal(t, tt.zero, ts.IsZero())
+			if !tt.zero {
+				require.Equal(t, tt.expected, ts.Unix())
+			}
+		})
+	}
+
+	var ts models.Timestamp
+	require.Error(t, json.Unmarshal([]byte(`"tomorrow"`), &ts))
+}
+
+func TestTimestampEncodesEpochSeconds(t *testing.T) {
+	data, err := json.Marshal(models.NewTimestamp(time.Unix(1700000000, 0)))
+	require.NoError(t, err)
+	require.JSONEq(t, `1700000000`, string(data))
+
+	data, err = json.Marshal(models.Timestamp{})
+	require.NoError(t, err)
+	require.JSONEq(t, `null`, string(data))
+}
+
+func TestParseSeverity(t *testing.T) {
+	require.Equal(t, models.SeverityAdvisory, models.ParseSeverity("advisory"))
+	require.Equal(t, models.SeverityWatch, models.ParseSeverity(" WATCH "))
+	require.Equal(t, models.SeverityWarning, models.ParseSeverity("Warning"))
+	require.Equal(t, models.SeverityWarning, models.ParseSeverity("Severe"))
+	require.Equal(t, models.SeverityUnknown, models.ParseSeverity("statement"))
+
+	var severity models.Severity
+	require.NoError(t, json.Unmarshal([]byte(`"Moderate"`), &severity))
+	require.Equal(t, models.SeverityWatch, severity)
+	require.NoError(t, json.Unmarshal([]byte(`null`), &severity))
+	require.Equal(t, models.SeverityUnknown, severity)
+
+	data, err := json.Marshal(models.SeverityAdvisory)
+	require.NoError(t, err)
+	require.JSONEq(t, `"advisory"`, string(data))
+}
+
+func TestAlertKeepsUnknownSeverity(t *testing.T) {
+	input := `{"title":"Special Weather Statement","regions":["Ottawa"],"severity":"Statement","time":1700000000,"expires":1700086400,"description":"Fog","uri":"https://example.com"}`
+
+	var alert models.Alert
+	require.NoError(t, json.Unmarshal([]byte(input), &alert))
+	require.Equal(t, models.SeverityUnknown, alert.Severity)
+	require.Equal(t, "Statement", alert.SeverityText())
+
+	data, err := json.Marshal(alert)
+	require.NoError(t, err)
+	require.JSONEq(t, input, string(data))
+
+	var known models.Alert
+	require.NoError(t, json.Unmarshal([]byte(`{"severity":"Severe"}`), &known))
+	require.Equal(t, "warning", known.SeverityText())
+	data, err = json.Marshal(known)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"severity":"warning"`)
+
+	known.Severity = models.SeverityUnknown
+	require.Equal(t, "unknown", known.SeverityText())
+}
+
+func TestAlertIsActive(t *testing.T) {
+	issued := time.Date(2023, 11, 14, 12, 0, 0, 0, time.UTC)
+	alert := models.Alert{
+		Severity: models.SeverityWatch,
+		Time:     models.NewTimestamp(issued),
+		Expires:  models.NewTimestamp(issued.Add(6 * time.Hour)),
+	}
+
+	require.False(t, alert.IsActive(issued.Add(-time.Minute)))
+	require.True(t, alert.IsActive(issued))
+	require.True(t, alert.IsActive(issued.Add(5*time.Hour)))
+	require.False(t, alert.IsActive(issued.Add(6*time.Hour)))
+	require.True(t, alert.IsExpired(issued.Add(6*time.Hour)))
+
+	open := models.Alert{Time: models.NewTimestamp(issued)}
+	require.True(t, open.IsActive(issued.AddDate(1, 0, 0)))
+	require.False(t, open.IsExpired(issued.AddDate(1, 0, 0)))
+
+	forecast := models.ForecastResponse{Alerts: []models.Alert{alert, open, {Severity: models.SeverityAdvisory}}}
+	require.Len(t, forecast.ActiveAlerts(issued.Add(7*time.Hour)), 2)
+	require.Len(t, forecast.AlertsAtLeast(models.SeverityWatch), 1)
+}
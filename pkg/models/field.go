@@ -0,0 +1,299 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldKind is the kind of value a DataPoint field holds
+type FieldKind int
+
+const (
+	// FieldFloat is a float64 measurement
+	FieldFloat FieldKind = iota
+	// FieldTime is an int64 Unix time in seconds
+	FieldTime
+	// FieldText is a string such as the summary, icon or precipitation type
+	FieldText
+)
+
+func (k FieldKind) String() string {
+	switch k {
+	case FieldFloat:
+		return "float"
+	case FieldTime:
+		return "time"
+	default:
+		return "text"
+	}
+}
+
+// Quantity is what a field measures, which determines its unit in each units system
+type Quantity int
+
+const (
+	QuantityNone Quantity = iota
+	QuantityTime
+	QuantityTemperature
+	QuantitySpeed
+	QuantityDistance
+	QuantityPrecipIntensity
+	QuantityPrecipAccumulation
+	QuantityPressure
+	QuantityBearing
+	QuantityFraction
+	QuantityIndex
+	QuantityOzone
+	QuantitySmoke
+)
+
+// quantityUnits gives the unit of each quantity in the si, ca, uk and us units systems
+var quantityUnits = map[Quantity][4]string{
+	QuantityTime:               {"s", "s", "s", "s"},
+	QuantityTemperature:        {"°C", "°C", "°C", "°F"},
+	QuantitySpeed:              {"m/s", "km/h", "mph", "mph"},
+	QuantityDistance:           {"km", "km", "mi", "mi"},
+	QuantityPrecipIntensity:    {"mm/h", "mm/h", "mm/h", "in/h"},
+	QuantityPrecipAccumulation: {"cm", "cm", "cm", "in"},
+	QuantityPressure:           {"hPa", "hPa", "hPa", "hPa"},
+	QuantityBearing:            {"°", "°", "°", "°"},
+	QuantityOzone:              {"DU", "DU", "DU", "DU"},
+	QuantitySmoke:              {"µg/m³", "µg/m³", "µg/m³", "µg/m³"},
+}
+
+var quantityNames = map[Quantity]string{
+	QuantityNone:               "none",
+	QuantityTime:               "time",
+	QuantityTemperature:        "temperature",
+	QuantitySpeed:              "speed",
+	QuantityDistance:           "distance",
+	QuantityPrecipIntensity:    "precipitation intensity",
+	QuantityPrecipAccumulation: "precipitation accumulation",
+	QuantityPressure:           "pressure",
+	QuantityBearing:            "bearing",
+	QuantityFraction:           "fraction",
+	QuantityIndex:              "index",
+	QuantityOzone:              "ozone",
+	QuantitySmoke:              "smoke",
+}
+
+func (q Quantity) String() string {
+	return quantityNames[q]
+}
+
+// Unit returns the unit symbol of the quantity in the units system (si, ca, uk or us; empty means us,
+// the API default). Fractions, indices and text have no unit and return "".
+func (q Quantity) Unit(units string) string {
+	symbols, ok := quantityUnits[q]
+	if !ok {
+		return ""
+	}
+	switch strings.ToLower(units) {
+	case "si":
+		return symbols[0]
+	case "ca":
+		return symbols[1]
+	case "uk", "uk2":
+		return symbols[2]
+	case "us", "":
+		return symbols[3]
+	default:
+		return ""
+	}
+}
+
+// Field describes a DataPoint field: its JSON name, kind and quantity, with accessors that work on any DataPoint
+type Field struct {
+	Name     string
+	Kind     FieldKind
+	Quantity Quantity
+
+	float func(*DataPoint) *float64
+	int   func(*DataPoint) *int64
+	text  func(*DataPoint) *string
+}
+
+func floatField(name string, quantity Quantity, field func(*DataPoint) *float64) Field {
+	return Field{Name: name, Kind: FieldFloat, Quantity: quantity, float: field}
+}
+
+func timeField(name string, field func(*DataPoint) *int64) Field {
+	return Field{Name: name, Kind: FieldTime, Quantity: QuantityTime, int: field}
+}
+
+func textField(name string, field func(*DataPoint) *string) Field {
+	return Field{Name: name, Kind: FieldText, text: field}
+}
+
+// fields lists every DataPoint field in struct order
+var fields = []Field{
+	timeField("time", func(d *DataPoint) *int64 { return &d.Time }),
+	textField("summary", func(d *DataPoint) *string { return &d.Summary }),
+	textField("icon", func(d *DataPoint) *string { return &d.Icon }),
+	floatField("nearestStormDistance", QuantityDistance, func(d *DataPoint) *float64 { return &d.NearestStormDistance }),
+	floatField("nearestStormBearing", QuantityBearing, func(d *DataPoint) *float64 { return &d.NearestStormBearing }),
+	floatField("precipIntensity", QuantityPrecipIntensity, func(d *DataPoint) *float64 { return &d.PrecipIntensity }),
+	floatField("precipProbability", QuantityFraction, func(d *DataPoint) *float64 { return &d.PrecipProbability }),
+	floatField("precipIntensityError", QuantityPrecipIntensity, func(d *DataPoint) *float64 { return &d.PrecipIntensityError }),
+	textField("precipType", func(d *DataPoint) *string { return &d.PrecipType }),
+	floatField("temperature", QuantityTemperature, func(d *DataPoint) *float64 { return &d.Temperature }),
+	floatField("apparentTemperature", QuantityTemperature, func(d *DataPoint) *float64 { return &d.ApparentTemperature }),
+	floatField("dewPoint", QuantityTemperature, func(d *DataPoint) *float64 { return &d.DewPoint }),
+	floatField("humidity", QuantityFraction, func(d *DataPoint) *float64 { return &d.Humidity }),
+	floatField("pressure", QuantityPressure, func(d *DataPoint) *float64 { return &d.Pressure }),
+	floatField("windSpeed", QuantitySpeed, func(d *DataPoint) *float64 { return &d.WindSpeed }),
+	floatField("windGust", QuantitySpeed, func(d *DataPoint) *float64 { return &d.WindGust }),
+	floatField("windBearing", QuantityBearing, func(d *DataPoint) *float64 { return &d.WindBearing }),
+	floatField("cloudCover", QuantityFraction, func(d *DataPoint) *float64 { return &d.CloudCover }),
+	floatField("uvIndex", QuantityIndex, func(d *DataPoint) *float64 { return &d.UVIndex }),
+	floatField("visibility", QuantityDistance, func(d *DataPoint) *float64 { return &d.Visibility }),
+	floatField("ozone", QuantityOzone, func(d *DataPoint) *float64 { return &d.Ozone }),
+	floatField("precipAccumulation", QuantityPrecipAccumulation, func(d *DataPoint) *float64 { return &d.PrecipAccumulation }),
+	floatField("temperatureHigh", QuantityTemperature, func(d *DataPoint) *float64 { return &d.TemperatureHigh }),
+	timeField("temperatureHighTime", func(d *DataPoint) *int64 { return &d.TemperatureHighTime }),
+	floatField("temperatureLow", QuantityTemperature, func(d *DataPoint) *float64 { return &d.TemperatureLow }),
+	timeField("temperatureLowTime", func(d *DataPoint) *int64 { return &d.TemperatureLowTime }),
+	floatField("apparentTemperatureHigh", QuantityTemperature, func(d *DataPoint) *float64 { return &d.ApparentTemperatureHigh }),
+	timeField("apparentTemperatureHighTime", func(d *DataPoint) *int64 { return &d.ApparentTemperatureHighTime }),
+	floatField("apparentTemperatureLow", QuantityTemperature, func(d *DataPoint) *float64 { return &d.ApparentTemperatureLow }),
+	timeField("apparentTemperatureLowTime", func(d *DataPoint) *int64 { return &d.ApparentTemperatureLowTime }),
+	floatField("moonPhase", QuantityFraction, func(d *DataPoint) *float64 { return &d.MoonPhase }),
+	floatField("precipIntensityMax", QuantityPrecipIntensity, func(d *DataPoint) *float64 { return &d.PrecipIntensityMax }),
+	timeField("precipIntensityMaxTime", func(d *DataPoint) *int64 { return &d.PrecipIntensityMaxTime }),
+	timeField("sunriseTime", func(d *DataPoint) *int64 { return &d.SunriseTime }),
+	timeField("sunsetTime", func(d *DataPoint) *int64 { return &d.SunsetTime }),
+	floatField("temperatureMin", QuantityTemperature, func(d *DataPoint) *float64 { return &d.TemperatureMin }),
+	timeField("temperatureMinTime", func(d *DataPoint) *int64 { return &d.TemperatureMinTime }),
+	floatField("temperatureMax", QuantityTemperature, func(d *DataPoint) *float64 { return &d.TemperatureMax }),
+	timeField("temperatureMaxTime", func(d *DataPoint) *int64 { return &d.TemperatureMaxTime }),
+	floatField("apparentTemperatureMin", QuantityTemperature, func(d *DataPoint) *float64 { return &d.ApparentTemperatureMin }),
+	timeField("apparentTemperatureMinTime", func(d *DataPoint) *int64 { return &d.ApparentTemperatureMinTime }),
+	floatField("apparentTemperatureMax", QuantityTemperature, func(d *DataPoint) *float64 { return &d.ApparentTemperatureMax }),
+	timeField("apparentTemperatureMaxTime", func(d *DataPoint) *int64 { return &d.ApparentTemperatureMaxTime }),
+	floatField("smoke", QuantitySmoke, func(d *DataPoint) *float64 { return &d.Smoke }),
+	floatField("fireIndex", QuantityIndex, func(d *DataPoint) *float64 { return &d.FireIndex }),
+	floatField("liquidAccumulation", QuantityPrecipAccumulation, func(d *DataPoint) *float64 { return &d.LiquidAccumulation }),
+	floatField("snowAccumulation", QuantityPrecipAccumulation, func(d *DataPoint) *float64 { return &d.SnowAccumulation }),
+	floatField("iceAccumulation", QuantityPrecipAccumulation, func(d *DataPoint) *float64 { return &d.IceAccumulation }),
+	timeField("dawnTime", func(d *DataPoint) *int64 { return &d.DawnTime }),
+	timeField("duskTime", func(d *DataPoint) *int64 { return &d.DuskTime }),
+}
+
+var fieldIndex = func() map[string]int {
+	index := make(map[string]int, len(fields))
+	for i, f := range fields {
+		index[f.Name] = i
+	}
+	return index
+}()
+
+// Fields returns every DataPoint field in struct order
+func Fields() []Field {
+	result := make([]Field, len(fields))
+	copy(result, fields)
+	return result
+}
+
+// LookupField returns the field with the given JSON name
+func LookupField(name string) (Field, bool) {
+	i, ok := fieldIndex[name]
+	if !ok {
+		return Field{}, false
+	}
+	return fields[i], true
+}
+
+// SelectFields returns the fields matching keep, in struct order
+func SelectFields(keep func(Field) bool) []Field {
+	var result []Field
+	for _, f := range fields {
+		if keep(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// IsNumeric reports whether the field holds a float or a time
+func (f Field) IsNumeric() bool {
+	return f.Kind != FieldText
+}
+
+// Unit returns the unit symbol of the field in the units system; see Quantity.Unit
+func (f Field) Unit(units string) string {
+	return f.Quantity.Unit(units)
+}
+
+// Value returns the value of a numeric field; ok is false for text fields and missing values
+func (f Field) Value(d *DataPoint) (float64, bool) {
+	return d.Value(f.Name)
+}
+
+// SetValue sets a numeric field and marks it present; it returns false for text fields
+func (f Field) SetValue(d *DataPoint, value float64) bool {
+	return d.SetValue(f.Name, value)
+}
+
+// Text returns the field formatted as text: text fields as they are, times as RFC3339 in the point's
+// location and floats in their shortest representation. Missing values format as "".
+func (f Field) Text(d *DataPoint) string {
+	switch f.Kind {
+	case FieldText:
+		return *f.text(d)
+	case FieldTime:
+		t, ok := d.TimeOf(f.Name)
+		if !ok {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	default:
+		value, ok := d.Value(f.Name)
+		if !ok {
+			return ""
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+}
+
+// SetText parses and sets the field, accepting what Text returns and Unix seconds for times.
+// An empty string marks a numeric field absent.
+func (f Field) SetText(d *DataPoint, text string) error {
+	if f.Kind == FieldText {
+		*f.text(d) = text
+		return nil
+	}
+	if text == "" {
+		d.SetAbsent(f.Name)
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil && f.Kind == FieldTime {
+		t, timeErr := time.Parse(time.RFC3339, text)
+		if timeErr == nil {
+			value, err = float64(t.Unix()), nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for field %s", text, f.Name)
+	}
+	d.SetValue(f.Name, value)
+	return nil
+}
+
+func (f Field) get(d *DataPoint) float64 {
+	if f.float != nil {
+		return *f.float(d)
+	}
+	return float64(*f.int(d))
+}
+
+func (f Field) set(d *DataPoint, value float64) {
+	if f.float != nil {
+		*f.float(d) = value
+		return
+	}
+	*f.int(d) = int64(value)
+}
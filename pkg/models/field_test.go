@@ -0,0 +1,96 @@
+package models_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsCoverDataPoint(t *testing.T) {
+	// Every exported DataPoint field is registered under its JSON name, in struct order
+	var names []string
+	typ := reflect.TypeOf(models.DataPoint{})
+	for i := 0; i < typ.NumField(); i++ {
+		if tag := typ.Field(i).Tag.Get("json"); tag != "" {
+			names = append(names, strings.Split(tag, ",")[0])
+		}
+	}
+
+	var registered []string
+	for _, field := range models.Fields() {
+		registered = append(registered, field.Name)
+	}
+	require.Equal(t, names, registered)
+}
+
+func TestFieldMetadata(t *testing.T) {
+	field, ok := models.LookupField("windGust")
+	require.True(t, ok)
+	require.Equal(t, models.FieldFloat, field.Kind)
+	require.Equal(t, models.QuantitySpeed, field.Quantity)
+	require.Equal(t, "m/s", field.Unit("si"))
+	require.Equal(t, "km/h", field.Unit("ca"))
+	require.Equal(t, "mph", field.Unit("uk"))
+	require.Equal(t, "mph", field.Unit("us"))
+
+	field, ok = models.LookupField("precipAccumulation")
+	require.True(t, ok)
+	require.Equal(t, "cm", field.Unit("si"))
+	require.Equal(t, "in", field.Unit(""))
+
+	field, ok = models.LookupField("sunriseTime")
+	require.True(t, ok)
+	require.Equal(t, models.FieldTime, field.Kind)
+
+	field, ok = models.LookupField("icon")
+	require.True(t, ok)
+	require.Equal(t, models.FieldText, field.Kind)
+	require.False(t, field.IsNumeric())
+	require.Empty(t, field.Unit("si"))
+
+	_, ok = models.LookupField("Temperature")
+	require.False(t, ok)
+
+	temperatures := models.SelectFields(func(f models.Field) bool { return f.Quantity == models.QuantityTemperature })
+	require.Len(t, temperatures, 11)
+}
+
+func TestFieldAccessors(t *testing.T) {
+	var point models.DataPoint
+	require.NoError(t, json.Unmarshal([]byte(`{"time": 1700000000, "icon": "rain", "temperature": 12.5, "humidity": null}`), &point))
+
+	temperature, _ := models.LookupField("temperature")
+	value, ok := temperature.Value(&point)
+	require.True(t, ok)
+	require.Equal(t, 12.5, value)
+	require.Equal(t, "12.5", temperature.Text(&point))
+
+	humidity, _ := models.LookupField("humidity")
+	require.Empty(t, humidity.Text(&point))
+	require.NoError(t, humidity.SetText(&point, "0.8"))
+	require.Equal(t, 0.8, point.Humidity)
+	require.Equal(t, models.ValuePresent, point.State("humidity"))
+	require.NoError(t, humidity.SetText(&point, ""))
+	require.Equal(t, models.ValueAbsent, point.State("humidity"))
+	require.Error(t, humidity.SetText(&point, "damp"))
+
+	icon, _ := models.LookupField("icon")
+	require.Equal(t, "rain", icon.Text(&point))
+	require.NoError(t, icon.SetText(&point, "snow"))
+	require.Equal(t, "snow", point.Icon)
+	_, ok = icon.Value(&point)
+	require.False(t, ok)
+	require.False(t, icon.SetValue(&point, 1))
+
+	at, _ := models.LookupField("time")
+	require.Equal(t, "2023-11-14T22:13:20Z", at.Text(&point))
+	require.NoError(t, at.SetText(&point, "2023-11-15T00:00:00Z"))
+	require.Equal(t, time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC).Unix(), point.Time)
+	require.NoError(t, at.SetText(&point, "1700000000"))
+	require.Equal(t, int64(1700000000), point.Time)
+}
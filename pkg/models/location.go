@@ -0,0 +1,165 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Location returns the forecast's IANA timezone, falling back to a fixed zone built from Offset
+// when the timezone is empty or unknown to the local tz database
+func (f *ForecastResponse) Location() *time.Location {
+	if f.Timezone != "" {
+		if loc, err := time.LoadLocation(f.Timezone); err == nil {
+			return loc
+		}
+	}
+
+	offset := int(f.Offset * 3600)
+	name := f.Timezone
+	if name == "" {
+		name = fmt.Sprintf("UTC%+03d:%02d", offset/3600, abs(offset%3600)/60)
+	}
+	return time.FixedZone(name, offset)
+}
+
+// Localize attaches the forecast's location to every data point, so that their time accessors return
+// local times. Decoding a ForecastResponse does this automatically; call it after building one in code.
+func (f *ForecastResponse) Localize() {
+	loc := f.Location()
+	if f.Currently != nil {
+		f.Currently.loc = loc
+	}
+	for _, block := range []*DataBlock{f.Minutely, f.Hourly, f.Daily} {
+		if block == nil {
+			continue
+		}
+		for i := range block.Data {
+			block.Data[i].loc = loc
+		}
+	}
+}
+
+// forecastResponseJSON has the fields of ForecastResponse without its JSON methods
+type forecastResponseJSON ForecastResponse
+
+// UnmarshalJSON decodes a forecast and localizes its data points
+func (f *ForecastResponse) UnmarshalJSON(data []byte) error {
+	var forecast forecastResponseJSON
+	if err := json.Unmarshal(data, &forecast); err != nil {
+		return err
+	}
+	*f = ForecastResponse(forecast)
+	f.Localize()
+	return nil
+}
+
+// Location returns the location the point's time accessors use: the forecast's zone once localized, otherwise UTC
+func (d *DataPoint) Location() *time.Location {
+	if d.loc == nil {
+		return time.UTC
+	}
+	return d.loc
+}
+
+// SetLocation sets the location the point's time accessors use
+func (d *DataPoint) SetLocation(loc *time.Location) {
+	d.loc = loc
+}
+
+// TimeOf returns the time field with the given JSON name, e.g. "temperatureHighTime", in the point's location.
+// ok is false when the field is missing or is not a time field.
+func (d *DataPoint) TimeOf(name string) (t time.Time, ok bool) {
+	i, found := numericFieldIndex[name]
+	if !found || numericFields[i].Kind != FieldTime {
+		return time.Time{}, false
+	}
+	value, ok := d.Value(name)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0).In(d.Location()), true
+}
+
+// LocalTime returns the start of the point's period
+func (d *DataPoint) LocalTime() time.Time {
+	return d.localTime("time")
+}
+
+// Sunrise returns the sunrise time of a daily point
+func (d *DataPoint) Sunrise() time.Time {
+	return d.localTime("sunriseTime")
+}
+
+// Sunset returns the sunset time of a daily point
+func (d *DataPoint) Sunset() time.Time {
+	return d.localTime("sunsetTime")
+}
+
+// Dawn returns the civil dawn time of a daily point (API version 2)
+func (d *DataPoint) Dawn() time.Time {
+	return d.localTime("dawnTime")
+}
+
+// Dusk returns the civil dusk time of a daily point (API version 2)
+func (d *DataPoint) Dusk() time.Time {
+	return d.localTime("duskTime")
+}
+
+// TemperatureHighAt returns the time of the daytime high of a daily point
+func (d *DataPoint) TemperatureHighAt() time.Time {
+	return d.localTime("temperatureHighTime")
+}
+
+// TemperatureLowAt returns the time of the overnight low of a daily point
+func (d *DataPoint) TemperatureLowAt() time.Time {
+	return d.localTime("temperatureLowTime")
+}
+
+// TemperatureMinAt returns the time of the calendar-day minimum of a daily point
+func (d *DataPoint) TemperatureMinAt() time.Time {
+	return d.localTime("temperatureMinTime")
+}
+
+// TemperatureMaxAt returns the time of the calendar-day maximum of a daily point
+func (d *DataPoint) TemperatureMaxAt() time.Time {
+	return d.localTime("temperatureMaxTime")
+}
+
+// ApparentTemperatureHighAt returns the time of the daytime apparent high of a daily point
+func (d *DataPoint) ApparentTemperatureHighAt() time.Time {
+	return d.localTime("apparentTemperatureHighTime")
+}
+
+// ApparentTemperatureLowAt returns the time of the overnight apparent low of a daily point
+func (d *DataPoint) ApparentTemperatureLowAt() time.Time {
+	return d.localTime("apparentTemperatureLowTime")
+}
+
+// ApparentTemperatureMinAt returns the time of the calendar-day apparent minimum of a daily point
+func (d *DataPoint) ApparentTemperatureMinAt() time.Time {
+	return d.localTime("apparentTemperatureMinTime")
+}
+
+// ApparentTemperatureMaxAt returns the time of the calendar-day apparent maximum of a daily point
+func (d *DataPoint) ApparentTemperatureMaxAt() time.Time {
+	return d.localTime("apparentTemperatureMaxTime")
+}
+
+// PrecipIntensityMaxAt returns the time of the peak precipitation intensity of a daily point
+func (d *DataPoint) PrecipIntensityMaxAt() time.Time {
+	return d.localTime("precipIntensityMaxTime")
+}
+
+// localTime returns the named time field, or the zero time when it is missing
+func (d *DataPoint) localTime(name string) time.Time {
+	t, _ := d.TimeOf(name)
+	return t
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,67 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastLocation(t *testing.T) {
+	forecast := models.ForecastResponse{Timezone: "America/Toronto", Offset: -4}
+	require.Equal(t, "America/Toronto", forecast.Location().String())
+
+	forecast = models.ForecastResponse{Timezone: "Not/AZone", Offset: 5.5}
+	_, offset := time.Unix(0, 0).In(forecast.Location()).Zone()
+	require.Equal(t, 5*3600+1800, offset)
+
+	forecast = models.ForecastResponse{Offset: -3.5}
+	_, offset = time.Unix(0, 0).In(forecast.Location()).Zone()
+	require.Equal(t, -(3*3600 + 1800), offset)
+}
+
+func TestDataPointTimesAreLocal(t *testing.T) {
+	body := `{
+		"timezone": "America/Toronto",
+		"offset": -4,
+		"currently": {"time": 1700000000},
+		"daily": {"data": [{
+			"time": 1699938000,
+			"sunriseTime": 1699964400,
+			"sunsetTime": 1699999200,
+			"temperatureHigh": 8,
+			"temperatureHighTime": 1699984800
+		}]}
+	}`
+
+	var forecast models.ForecastResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &forecast))
+
+	now := forecast.Currently.LocalTime()
+	require.Equal(t, "America/Toronto", now.Location().String())
+	require.Equal(t, int64(1700000000), now.Unix())
+
+	day := forecast.Daily.Data[0]
+	require.Equal(t, 0, day.LocalTime().Hour())
+	require.Equal(t, 7, day.Sunrise().Hour())
+	require.Equal(t, 17, day.Sunset().Hour())
+	require.Equal(t, 13, day.TemperatureHighAt().Hour())
+
+	// Absent time fields give the zero time rather than the epoch
+	require.True(t, day.Dawn().IsZero())
+	_, ok := day.TimeOf("dawnTime")
+	require.False(t, ok)
+	_, ok = day.TimeOf("temperature")
+	require.False(t, ok)
+}
+
+func TestDataPointWithoutLocationUsesUTC(t *testing.T) {
+	point := models.DataPoint{Time: 1700000000}
+	require.Equal(t, time.UTC, point.LocalTime().Location())
+
+	forecast := models.ForecastResponse{Timezone: "Asia/Tokyo", Currently: &point}
+	forecast.Localize()
+	require.Equal(t, "Asia/Tokyo", forecast.Currently.LocalTime().Location().String())
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+)
+
+// MissingValue is the sentinel the API sends when a value is unavailable
+const MissingValue = -999
+
+// ValueState describes whether a DataPoint field holds a real value
+type ValueState int
+
+const (
+	// ValuePresent is a real value, including a real zero
+	ValuePresent ValueState = iota
+	// ValueAbsent is a field the API omitted or sent as null
+	ValueAbsent
+	// ValueSentinel is a field holding the MissingValue sentinel
+	ValueSentinel
+	// ValueUnknown is a name that is not a numeric DataPoint field
+	ValueUnknown
+)
+
+func (s ValueState) String() string {
+	switch s {
+	case ValuePresent:
+		return "present"
+	case ValueAbsent:
+		return "absent"
+	case ValueSentinel:
+		return "sentinel"
+	default:
+		return "unknown"
+	}
+}
+
+// fieldSet is a bit set indexed by position in numericFields
+type fieldSet uint64
+
+// numericFields are the float and time fields; their positions index fieldSet
+var numericFields = SelectFields(Field.IsNumeric)
+
+var numericFieldIndex = func() map[string]int {
+	index := make(map[string]int, len(numericFields))
+	for i, f := range numericFields {
+		index[f.Name] = i
+	}
+	return index
+}()
+
+// State reports whether the numeric field with the given JSON name is present, absent or the sentinel
+func (d *DataPoint) State(name string) ValueState {
+	i, ok := numericFieldIndex[name]
+	if !ok {
+		return ValueUnknown
+	}
+	if d.absent&(1<<i) != 0 {
+		return ValueAbsent
+	}
+	if numericFields[i].get(d) == MissingValue {
+		return ValueSentinel
+	}
+	return ValuePresent
+}
+
+// Value returns the numeric field with the given JSON name, e.g. "temperature".
+// ok is false when the field is absent, holds the sentinel or is not a numeric field.
+// Time fields are returned as Unix seconds.
+func (d *DataPoint) Value(name string) (value float64, ok bool) {
+	if d.State(name) != ValuePresent {
+		return 0, false
+	}
+	return numericFields[numericFieldIndex[name]].get(d), true
+}
+
+// IsMissing reports whether the numeric field is absent or holds the sentinel
+func (d *DataPoint) IsMissing(name string) bool {
+	state := d.State(name)
+	return state == ValueAbsent || state == ValueSentinel
+}
+
+// SetValue sets the numeric field with the given JSON name and marks it present.
+// It returns false if name is not a numeric field.
+func (d *DataPoint) SetValue(name string, value float64) bool {
+	i, ok := numericFieldIndex[name]
+	if !ok {
+		return false
+	}
+	numericFields[i].set(d, value)
+	d.absent &^= 1 << i
+	return true
+}
+
+// SetAbsent marks the numeric fields as absent and zeroes them
+func (d *DataPoint) SetAbsent(names ...string) {
+	for _, name := range names {
+		if i, ok := numericFieldIndex[name]; ok {
+			numericFields[i].set(d, 0)
+			d.absent |= 1 << i
+		}
+	}
+}
+
+// PresentFields returns the JSON names of the numeric fields holding real values
+func (d *DataPoint) PresentFields() []string {
+	var names []string
+	for _, f := range numericFields {
+		if d.State(f.Name) == ValuePresent {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// dataPointJSON has the fields of DataPoint without its JSON methods
+type dataPointJSON DataPoint
+
+// UnmarshalJSON decodes a data point, recording which numeric fields were omitted or null
+func (d *DataPoint) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var point dataPointJSON
+	if err := json.Unmarshal(data, &point); err != nil {
+		return err
+	}
+
+	*d = DataPoint(point)
+	d.absent = 0
+	for i, f := range numericFields {
+		value, found := raw[f.Name]
+		if !found || bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			d.absent |= 1 << i
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes a data point, leaving out absent fields so that they are not turned into zeros
+func (d DataPoint) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(dataPointJSON(d))
+	if err != nil || d.absent == 0 {
+		return data, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for i, f := range numericFields {
+		if d.absent&(1<<i) != 0 {
+			delete(raw, f.Name)
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// Values returns the real values of the numeric field across the block, skipping missing ones
+func (b *DataBlock) Values(name string) []float64 {
+	var values []float64
+	for i := range b.Data {
+		if value, ok := b.Data[i].Value(name); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Sum returns the sum of the real values of the field; ok is false if there are none
+func (b *DataBlock) Sum(name string) (sum float64, ok bool) {
+	values := b.Values(name)
+	for _, value := range values {
+		sum += value
+	}
+	return sum, len(values) > 0
+}
+
+// Mean returns the mean of the real values of the field; ok is false if there are none
+func (b *DataBlock) Mean(name string) (float64, bool) {
+	values := b.Values(name)
+	if len(values) == 0 {
+		return 0, false
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values)), true
+}
+
+// Min returns the smallest real value of the field; ok is false if there are none
+func (b *DataBlock) Min(name string) (float64, bool) {
+	return b.reduce(name, math.Min)
+}
+
+// Max returns the largest real value of the field; ok is false if there are none
+func (b *DataBlock) Max(name string) (float64, bool) {
+	return b.reduce(name, math.Max)
+}
+
+func (b *DataBlock) reduce(name string, pick func(a, b float64) float64) (float64, bool) {
+	values := b.Values(name)
+	if len(values) == 0 {
+		return 0, false
+	}
+	result := values[0]
+	for _, value := range values[1:] {
+		result = pick(result, value)
+	}
+	return result, true
+}
@@ -0,0 +1,130 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPointDistinguishesMissingValues(t *testing.T) {
+	var point models.DataPoint
+	err := json.Unmarshal([]byte(`{"time": 1620000000, "temperature": 0, "humidity": null, "pressure": -999}`), &point)
+	require.NoError(t, err)
+
+	require.Equal(t, models.ValuePresent, point.State("temperature"))
+	require.Equal(t, models.ValueAbsent, point.State("humidity"))
+	require.Equal(t, models.ValueAbsent, point.State("windSpeed"))
+	require.Equal(t, models.ValueSentinel, point.State("pressure"))
+	require.Equal(t, models.ValueUnknown, point.State("summary"))
+
+	temperature, ok := point.Value("temperature")
+	require.True(t, ok)
+	require.Zero(t, temperature)
+
+	_, ok = point.Value("humidity")
+	require.False(t, ok)
+	_, ok = point.Value("pressure")
+	require.False(t, ok)
+	require.True(t, point.IsMissing("pressure"))
+	require.True(t, point.IsMissing("windSpeed"))
+
+	timestamp, ok := point.Value("time")
+	require.True(t, ok)
+	require.Equal(t, 1620000000.0, timestamp)
+
+	require.Equal(t, []string{"time", "temperature"}, point.PresentFields())
+}
+
+func TestDataPointJSONRoundTrip(t *testing.T) {
+	input := `{"time":1620000000,"summary":"Clear","icon":"clear-day","precipType":"none","temperature":0,"pressure":-999}`
+
+	var point models.DataPoint
+	require.NoError(t, json.Unmarshal([]byte(input), &point))
+
+	output, err := json.Marshal(point)
+	require.NoError(t, err)
+	require.JSONEq(t, input, string(output))
+
+	var decoded models.DataPoint
+	require.NoError(t, json.Unmarshal(output, &decoded))
+	require.Equal(t, point, decoded)
+}
+
+func TestDataPointRoundTripKeepsZeroStormDistanceAndBearing(t *testing.T) {
+	input := `{"time":1620000000,"summary":"Thunderstorm","icon":"thunderstorm","precipType":"rain","nearestStormDistance":0,"nearestStormBearing":0}`
+
+	var point models.DataPoint
+	require.NoError(t, json.Unmarshal([]byte(input), &point))
+	require.Equal(t, models.ValuePresent, point.State("nearestStormDistance"))
+	require.Equal(t, models.ValuePresent, point.State("nearestStormBearing"))
+
+	output, err := json.Marshal(point)
+	require.NoError(t, err)
+	require.JSONEq(t, input, string(output))
+
+	var decoded models.DataPoint
+	require.NoError(t, json.Unmarshal(output, &decoded))
+	distance, ok := decoded.Value("nearestStormDistance")
+	require.True(t, ok)
+	require.Zero(t, distance)
+	bearing, ok := decoded.Value("nearestStormBearing")
+	require.True(t, ok)
+	require.Zero(t, bearing)
+}
+
+func TestDataPointBuiltInCodeIsPresent(t *testing.T) {
+	point := models.DataPoint{Time: 1620000000, Temperature: 20.5}
+
+	humidity, ok := point.Value("humidity")
+	require.True(t, ok)
+	require.Zero(t, humidity)
+
+	point.SetAbsent("humidity")
+	require.Equal(t, models.ValueAbsent, point.State("humidity"))
+
+	output, err := json.Marshal(point)
+	require.NoError(t, err)
+	require.NotContains(t, string(output), "humidity")
+	require.Contains(t, string(output), `"windSpeed":0`)
+	require.Contains(t, string(output), `"nearestStormDistance":0`)
+	require.Contains(t, string(output), `"dawnTime":0`)
+
+	require.True(t, point.SetValue("humidity", 0.5))
+	require.Equal(t, models.ValuePresent, point.State("humidity"))
+	require.Equal(t, 0.5, point.Humidity)
+	require.False(t, point.SetValue("summary", 1))
+}
+
+func TestDataBlockAggregationSkipsMissingValues(t *testing.T) {
+	var block models.DataBlock
+	err := json.Unmarshal([]byte(`{"data": [
+		{"time": 0, "temperature": 10, "precipAccumulation": 0.5},
+		{"time": 3600, "temperature": -999},
+		{"time": 7200, "precipAccumulation": 1.5},
+		{"time": 10800, "temperature": 20, "precipAccumulation": null}
+	]}`), &block)
+	require.NoError(t, err)
+
+	require.Equal(t, []float64{10, 20}, block.Values("temperature"))
+
+	mean, ok := block.Mean("temperature")
+	require.True(t, ok)
+	require.Equal(t, 15.0, mean)
+
+	low, ok := block.Min("temperature")
+	require.True(t, ok)
+	require.Equal(t, 10.0, low)
+
+	high, ok := block.Max("temperature")
+	require.True(t, ok)
+	require.Equal(t, 20.0, high)
+
+	total, ok := block.Sum("precipAccumulation")
+	require.True(t, ok)
+	require.Equal(t, 2.0, total)
+
+	_, ok = block.Mean("humidity")
+	require.False(t, ok)
+}
@@ -18,13 +18,21 @@ type ForecastResponse struct {
 	SourceIDX *SourceIDX `json:"sourceIDX,omitempty"`
 }
 
-// DataPoint represents a single weather data point
+// DataPoint represents a single weather data point.
+//
+// Numeric fields the API omits (or sends as null) are recorded as absent, and fields holding
+// the API's MissingValue sentinel are reported as missing; use Value and State to tell them
+// apart from real zeros. A DataPoint built in code treats every field as present; SetAbsent leaves
+// fields out of its JSON encoding.
+//
+// Time fields are Unix seconds; accessors such as LocalTime and Sunrise return them in the
+// forecast's timezone (see ForecastResponse.Localize).
 type DataPoint struct {
 	Time                        int64   `json:"time"`
 	Summary                     string  `json:"summary"`
 	Icon                        string  `json:"icon"`
-	NearestStormDistance        float64 `json:"nearestStormDistance,omitempty"`
-	NearestStormBearing         float64 `json:"nearestStormBearing,omitempty"`
+	NearestStormDistance        float64 `json:"nearestStormDistance"`
+	NearestStormBearing         float64 `json:"nearestStormBearing"`
 	PrecipIntensity             float64 `json:"precipIntensity"`
 	PrecipProbability           float64 `json:"precipProbability"`
 	PrecipIntensityError        float64 `json:"precipIntensityError"`
@@ -63,13 +71,16 @@ type DataPoint struct {
 	ApparentTemperatureMinTime  int64   `json:"apparentTemperatureMinTime"`
 	ApparentTemperatureMax      float64 `json:"apparentTemperatureMax"`
 	ApparentTemperatureMaxTime  int64   `json:"apparentTemperatureMaxTime"`
-	Smoke                       float64 `json:"smoke,omitempty"`
-	FireIndex                   float64 `json:"fireIndex,omitempty"`
-	LiquidAccumulation          float64 `json:"liquidAccumulation,omitempty"`
-	SnowAccumulation            float64 `json:"snowAccumulation,omitempty"`
-	IceAccumulation             float64 `json:"iceAccumulation,omitempty"`
-	DawnTime                    int64   `json:"dawnTime,omitempty"`
-	DuskTime                    int64   `json:"duskTime,omitempty"`
+	Smoke                       float64 `json:"smoke"`
+	FireIndex                   float64 `json:"fireIndex"`
+	LiquidAccumulation          float64 `json:"liquidAccumulation"`
+	SnowAccumulation            float64 `json:"snowAccumulation"`
+	IceAccumulation             float64 `json:"iceAccumulation"`
+	DawnTime                    int64   `json:"dawnTime"`
+	DuskTime                    int64   `json:"duskTime"`
+
+	absent fieldSet
+	loc    *time.Location
 }
 
 // DataBlock represents a block of weather data points
@@ -79,15 +90,18 @@ type DataBlock struct {
 	Data    []DataPoint `json:"data"`
 }
 
-// Alert represents a weather alert
+// Alert represents a weather alert. A severity the SDK does not recognise decodes as SeverityUnknown
+// and keeps its text, which SeverityText returns and MarshalJSON encodes again.
 type Alert struct {
 	Title       string    `json:"title"`
 	Regions     []string  `json:"regions"`
-	Severity    string    `json:"severity"`
-	Time        time.Time `json:"time"`
-	Expires     time.Time `json:"expires"`
+	Severity    Severity  `json:"severity"`
+	Time        Timestamp `json:"time"`
+	Expires     Timestamp `json:"expires"`
 	Description string    `json:"description"`
 	URI         string    `json:"uri"`
+
+	severityText string
 }
 
 // Flags represents additional metadata about the forecast
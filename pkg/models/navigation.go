@@ -0,0 +1,152 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNoMinutely is returned when the forecast has no minutely block, e.g. because it was excluded
+	ErrNoMinutely = errors.New("forecast has no minutely block")
+
+	// ErrNoHourly is returned when the forecast has no hourly block, e.g. because it was excluded
+	ErrNoHourly = errors.New("forecast has no hourly block")
+
+	// ErrNoDaily is returned when the forecast has no daily block, e.g. because it was excluded
+	ErrNoDaily = errors.New("forecast has no daily block")
+
+	// ErrNoData is returned when no data point covers the requested day or time
+	ErrNoData = errors.New("no data point for the requested time")
+)
+
+// Now returns the time the forecast describes as current: the time of the currently block,
+// or the first hourly or daily point when it is missing. Today and NextHours are relative to it,
+// so a cached forecast keeps answering for the moment it was issued.
+func (f *ForecastResponse) Now() time.Time {
+	loc := f.Location()
+	if f.Currently != nil {
+		if t, ok := f.Currently.TimeOf("time"); ok {
+			return t.In(loc)
+		}
+	}
+	for _, block := range []*DataBlock{f.Hourly, f.Daily} {
+		if block != nil && len(block.Data) > 0 {
+			return time.Unix(block.Data[0].Time, 0).In(loc)
+		}
+	}
+	return time.Now().In(loc)
+}
+
+// Today returns the daily point of the forecast's current local day
+func (f *ForecastResponse) Today() (*DataPoint, error) {
+	return f.Day(f.Now())
+}
+
+// Tomorrow returns the daily point of the day after the forecast's current local day
+func (f *ForecastResponse) Tomorrow() (*DataPoint, error) {
+	return f.Day(f.Now().AddDate(0, 0, 1))
+}
+
+// Day returns the daily point for the calendar date of date. Only the year, month and day of date
+// are used, so time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC) selects March 10 in the forecast's timezone.
+func (f *ForecastResponse) Day(date time.Time) (*DataPoint, error) {
+	if f.Daily == nil {
+		return nil, ErrNoDaily
+	}
+	loc := f.Location()
+	for i := range f.Daily.Data {
+		if sameDate(time.Unix(f.Daily.Data[i].Time, 0).In(loc), date) {
+			return &f.Daily.Data[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoData, date.Format("2006-01-02"))
+}
+
+// HourlyForDay returns the hourly points of the calendar date of date in the forecast's timezone.
+// A day with a daylight saving transition has 23 or 25 points.
+func (f *ForecastResponse) HourlyForDay(date time.Time) ([]DataPoint, error) {
+	if f.Hourly == nil {
+		return nil, ErrNoHourly
+	}
+	loc := f.Location()
+	var points []DataPoint
+	for _, point := range f.Hourly.Data {
+		if sameDate(time.Unix(point.Time, 0).In(loc), date) {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+// NextHours returns up to n hourly points starting with the hour containing Now.
+// It returns fewer points when the hourly block ends sooner.
+func (f *ForecastResponse) NextHours(n int) ([]DataPoint, error) {
+	if f.Hourly == nil {
+		return nil, ErrNoHourly
+	}
+	// The hour containing Now is the first one that has not ended. Hours are not truncated in
+	// absolute time, as they start on the half hour in zones such as Asia/Kolkata.
+	now := f.Now().Unix()
+	var points []DataPoint
+	for _, point := range f.Hourly.Data {
+		if len(points) == n {
+			break
+		}
+		if point.Time+int64(time.Hour/time.Second) > now {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+// Between returns the hourly points starting in [from, to)
+func (f *ForecastResponse) Between(from, to time.Time) ([]DataPoint, error) {
+	if f.Hourly == nil {
+		return nil, ErrNoHourly
+	}
+	var points []DataPoint
+	for _, point := range f.Hourly.Data {
+		if point.Time >= from.Unix() && point.Time < to.Unix() {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+// PointAt returns the most detailed data point covering t: the minute, the hour, or the day.
+// It returns ErrNoData when t is outside every block.
+func (f *ForecastResponse) PointAt(t time.Time) (*DataPoint, error) {
+	if f.Minutely == nil && f.Hourly == nil && f.Daily == nil {
+		return nil, errors.Join(ErrNoMinutely, ErrNoHourly, ErrNoDaily)
+	}
+
+	loc := f.Location()
+	blocks := []struct {
+		block *DataBlock
+		end   func(start time.Time) time.Time
+	}{
+		{f.Minutely, func(start time.Time) time.Time { return start.Add(time.Minute) }},
+		{f.Hourly, func(start time.Time) time.Time { return start.Add(time.Hour) }},
+		{f.Daily, func(start time.Time) time.Time { return start.AddDate(0, 0, 1) }},
+	}
+	for _, b := range blocks {
+		if b.block == nil {
+			continue
+		}
+		for i := range b.block.Data {
+			start := time.Unix(b.block.Data[i].Time, 0).In(loc)
+			if !t.Before(start) && t.Before(b.end(start)) {
+				return &b.block.Data[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoData, t.Format(time.RFC3339))
+}
+
+// sameDate reports whether t falls on the calendar date of date
+func sameDate(t, date time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := date.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
@@ -0,0 +1,113 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastNavigation(t *testing.T) {
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	start := time.Date(2023, 11, 3, 14, 20, 0, 0, toronto)
+	forecast := synthetic.Generate(43.65, -79.38, start, synthetic.WithTimezone("America/Toronto"), synthetic.WithHours(96))
+
+	today, err := forecast.Today()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 11, 3, 0, 0, 0, 0, toronto).Unix(), today.Time)
+
+	tomorrow, err := forecast.Tomorrow()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 11, 4, 0, 0, 0, 0, toronto).Unix(), tomorrow.Time)
+
+	// The date's calendar fields select the day, whatever its location
+	day, err := forecast.Day(time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 6, day.LocalTime().Day())
+
+	_, err = forecast.Day(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, models.ErrNoData)
+
+	next, err := forecast.NextHours(6)
+	require.NoError(t, err)
+	require.Len(t, next, 6)
+	require.Equal(t, time.Date(2023, 11, 3, 14, 0, 0, 0, toronto).Unix(), next[0].Time)
+
+	// Hours start on the half hour in absolute time in a +05:30 zone
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	noon := time.Date(2024, 3, 1, 12, 0, 0, 0, kolkata)
+	india := &models.ForecastResponse{
+		Timezone:  "Asia/Kolkata",
+		Currently: &models.DataPoint{Time: noon.Add(2*time.Hour + 40*time.Minute).Unix()},
+		Hourly:    &models.DataBlock{},
+	}
+	for i := 0; i < 6; i++ {
+		india.Hourly.Data = append(india.Hourly.Data, models.DataPoint{Time: noon.Add(time.Duration(i) * time.Hour).Unix()})
+	}
+	next, err = india.NextHours(2)
+	require.NoError(t, err)
+	require.Len(t, next, 2)
+	require.Equal(t, noon.Add(2*time.Hour).Unix(), next[0].Time)
+
+	from := time.Date(2023, 11, 4, 9, 0, 0, 0, toronto)
+	between, err := forecast.Between(from, from.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, between, 3)
+	require.Equal(t, from.Unix(), between[0].Time)
+
+	point, err := forecast.PointAt(start.Add(90 * time.Second))
+	require.NoError(t, err)
+	require.Equal(t, start.Add(time.Minute).Unix(), point.Time)
+
+	point, err = forecast.PointAt(from.Add(30 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, from.Unix(), point.Time)
+
+	point, err = forecast.PointAt(time.Date(2023, 11, 9, 12, 0, 0, 0, toronto))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 11, 9, 0, 0, 0, 0, toronto).Unix(), point.Time)
+
+	_, err = forecast.PointAt(start.AddDate(1, 0, 0))
+	require.ErrorIs(t, err, models.ErrNoData)
+}
+
+func TestHourlyForDayAcrossDaylightSaving(t *testing.T) {
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	start := time.Date(2023, 11, 4, 0, 0, 0, 0, toronto)
+	forecast := synthetic.Generate(43.65, -79.38, start, synthetic.WithTimezone("America/Toronto"), synthetic.WithHours(72))
+
+	// Clocks go back on November 5, giving a 25 hour day
+	hours, err := forecast.HourlyForDay(time.Date(2023, 11, 5, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, hours, 25)
+
+	hours, err = forecast.HourlyForDay(time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, hours, 24)
+
+	day, err := forecast.Day(time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 0, day.LocalTime().Hour())
+}
+
+func TestNavigationReportsExcludedBlocks(t *testing.T) {
+	forecast := &models.ForecastResponse{Timezone: "UTC", Currently: &models.DataPoint{Time: 1700000000}}
+
+	_, err := forecast.Today()
+	require.ErrorIs(t, err, models.ErrNoDaily)
+	_, err = forecast.NextHours(6)
+	require.ErrorIs(t, err, models.ErrNoHourly)
+	_, err = forecast.HourlyForDay(time.Now())
+	require.ErrorIs(t, err, models.ErrNoHourly)
+	_, err = forecast.Between(time.Now(), time.Now().Add(time.Hour))
+	require.ErrorIs(t, err, models.ErrNoHourly)
+
+	_, err = forecast.PointAt(time.Unix(1700000000, 0))
+	require.True(t, errors.Is(err, models.ErrNoMinutely) && errors.Is(err, models.ErrNoDaily))
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time decoded from Unix epoch seconds, an RFC3339 string or null.
+// It encodes as epoch seconds like the API, and a zero Timestamp encodes as null.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t in a Timestamp
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// UnmarshalJSON accepts epoch seconds (as a number or numeric string), RFC3339 and null
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			t.Time = time.Time{}
+			return nil
+		}
+	}
+
+	if epoch, err := strconv.ParseFloat(text, 64); err == nil {
+		seconds := int64(epoch)
+		t.Time = time.Unix(seconds, int64((epoch-float64(seconds))*1e9))
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s: expected epoch seconds or RFC3339", data)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON encodes the timestamp as epoch seconds, or null when zero
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Units systems accepted by the API
+const (
+	UnitsSI = "si"
+	UnitsUS = "us"
+	UnitsUK = "uk"
+	UnitsCA = "ca"
+)
+
+// ErrUnknownUnits is returned for a units system other than si, us, uk or ca
+var ErrUnknownUnits = errors.New("unknown units system")
+
+// unitScales converts each unit to the SI unit of its quantity: si = value*scale + offset
+var unitScales = map[string]struct{ scale, offset float64 }{
+	"°F":   {5.0 / 9, -32 * 5.0 / 9},
+	"km/h": {1 / 3.6, 0},
+	"mph":  {0.44704, 0},
+	"mi":   {1.609344, 0},
+	"in/h": {25.4, 0},
+	"in":   {2.54, 0},
+}
+
+// normalizeUnits returns the canonical name of a units system; uk2 is the API's old name for uk,
+// and no units at all means us, the API's default
+func normalizeUnits(units string) (string, error) {
+	switch strings.ToLower(units) {
+	case "":
+		return UnitsUS, nil
+	case UnitsSI, UnitsUS, UnitsCA:
+		return strings.ToLower(units), nil
+	case UnitsUK, "uk2":
+		return UnitsUK, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownUnits, units)
+	}
+}
+
+// ConvertValue converts a value of the quantity from one units system to another.
+// Quantities measured the same way in every system, such as pressure (hPa) and fractions, are returned unchanged.
+func ConvertValue(q Quantity, value float64, from, to string) (float64, error) {
+	from, err := normalizeUnits(from)
+	if err != nil {
+		return 0, err
+	}
+	to, err = normalizeUnits(to)
+	if err != nil {
+		return 0, err
+	}
+
+	fromUnit, toUnit := q.Unit(from), q.Unit(to)
+	if fromUnit == toUnit {
+		return value, nil
+	}
+	if s, ok := unitScales[fromUnit]; ok {
+		value = value*s.scale + s.offset
+	}
+	if s, ok := unitScales[toUnit]; ok {
+		value = (value - s.offset) / s.scale
+	}
+	return value, nil
+}
+
+// Units returns the units system of the forecast from its flags, or "" when the flags were excluded.
+// Conversions read "" as us, the API's default.
+func (f *ForecastResponse) Units() string {
+	if f.Flags == nil {
+		return ""
+	}
+	return f.Flags.Units
+}
+
+// ConvertUnits returns a copy of the forecast converted to the units system, with Flags.Units set.
+// Temperatures, speeds, distances, precipitation intensities and accumulations are converted;
+// pressure is in hPa in every system. Missing values stay missing. The forecast itself is not modified,
+// so a cached response can be served in several units systems.
+func (f *ForecastResponse) ConvertUnits(to string) (*ForecastResponse, error) {
+	from, err := normalizeUnits(f.Units())
+	if err != nil {
+		return nil, err
+	}
+	to, err = normalizeUnits(to)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := f.Clone()
+	if converted.Currently != nil {
+		if err := converted.Currently.ConvertUnits(from, to); err != nil {
+			return nil, err
+		}
+	}
+	for _, block := range []*DataBlock{converted.Minutely, converted.Hourly, converted.Daily} {
+		if block == nil {
+			continue
+		}
+		for i := range block.Data {
+			if err := block.Data[i].ConvertUnits(from, to); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if converted.Flags == nil {
+		converted.Flags = &Flags{}
+	}
+	converted.Flags.Units = to
+	return converted, nil
+}
+
+// ConvertUnits converts the point's measurements in place from one units system to another
+func (d *DataPoint) ConvertUnits(from, to string) error {
+	for _, field := range numericFields {
+		if field.Kind != FieldFloat {
+			continue
+		}
+		value, ok := d.Value(field.Name)
+		if !ok {
+			continue
+		}
+		converted, err := ConvertValue(field.Quantity, value, from, to)
+		if err != nil {
+			return err
+		}
+		field.set(d, converted)
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the forecast
+func (f *ForecastResponse) Clone() *ForecastResponse {
+	clone := *f
+	if f.Currently != nil {
+		currently := *f.Currently
+		clone.Currently = &currently
+	}
+	clone.Minutely = f.Minutely.clone()
+	clone.Hourly = f.Hourly.clone()
+	clone.Daily = f.Daily.clone()
+	if f.Alerts != nil {
+		clone.Alerts = make([]Alert, len(f.Alerts))
+		for i, alert := range f.Alerts {
+			alert.Regions = append([]string(nil), alert.Regions...)
+			clone.Alerts[i] = alert
+		}
+	}
+	if f.Flags != nil {
+		flags := *f.Flags
+		flags.Sources = append([]string(nil), f.Flags.Sources...)
+		if f.Flags.SourceTimes != nil {
+			flags.SourceTimes = make(map[string]string, len(f.Flags.SourceTimes))
+			for source, t := range f.Flags.SourceTimes {
+				flags.SourceTimes[source] = t
+			}
+		}
+		clone.Flags = &flags
+	}
+	if f.SourceIDX != nil {
+		sourceIDX := *f.SourceIDX
+		clone.SourceIDX = &sourceIDX
+	}
+	return &clone
+}
+
+func (b *DataBlock) clone() *DataBlock {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.Data = append([]DataPoint(nil), b.Data...)
+	return &clone
+}
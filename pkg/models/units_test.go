@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertValue(t *testing.T) {
+	testCases := []struct {
+		quantity models.Quantity
+		value    float64
+		from, to string
+		expected float64
+	}{
+		{models.QuantityTemperature, 20, "si", "us", 68},
+		{models.QuantityTemperature, 212, "us", "ca", 100},
+		{models.QuantitySpeed, 10, "si", "ca", 36},
+		{models.QuantitySpeed, 10, "si", "uk", 22.3694},
+		{models.QuantitySpeed, 100, "ca", "us", 62.1371},
+		{models.QuantityDistance, 16.09344, "si", "uk2", 10},
+		{models.QuantityPrecipIntensity, 25.4, "si", "us", 1},
+		{models.QuantityPrecipAccumulation, 1, "us", "si", 2.54},
+		{models.QuantityPressure, 1013.25, "si", "us", 1013.25},
+		{models.QuantityFraction, 0.5, "si", "us", 0.5},
+	}
+
+	for _, tc := range testCases {
+		result, err := models.ConvertValue(tc.quantity, tc.value, tc.from, tc.to)
+		require.NoError(t, err)
+		require.InDelta(t, tc.expected, result, 1e-3, "%s %s→%s", tc.quantity, tc.from, tc.to)
+	}
+
+	// Forecasts without flags are in us, the API's default
+	result, err := models.ConvertValue(models.QuantityTemperature, 212, "", "si")
+	require.NoError(t, err)
+	require.InDelta(t, 100, result, 1e-9)
+
+	_, err = models.ConvertValue(models.QuantityTemperature, 1, "si", "metric")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestForecastConvertUnits(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	si := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("si"))
+	us := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("us"))
+
+	converted, err := si.ConvertUnits("us")
+	require.NoError(t, err)
+	require.Equal(t, "us", converted.Flags.Units)
+	require.Equal(t, "si", si.Flags.Units, "the original forecast is not modified")
+
+	require.InDelta(t, us.Currently.Temperature, converted.Currently.Temperature, 0.01)
+	require.InDelta(t, us.Currently.WindSpeed, converted.Currently.WindSpeed, 0.01)
+	require.InDelta(t, us.Currently.Visibility, converted.Currently.Visibility, 0.01)
+	require.Equal(t, us.Currently.Pressure, converted.Currently.Pressure)
+	for i := range us.Daily.Data {
+		require.InDelta(t, us.Daily.Data[i].TemperatureHigh, converted.Daily.Data[i].TemperatureHigh, 0.01)
+		require.InDelta(t, us.Daily.Data[i].PrecipAccumulation, converted.Daily.Data[i].PrecipAccumulation, 0.001)
+	}
+
+	back, err := converted.ConvertUnits("si")
+	require.NoError(t, err)
+	require.InDelta(t, si.Currently.Temperature, back.Currently.Temperature, 1e-9)
+
+	_, err = si.ConvertUnits("kelvin")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+
+	unflagged := us.Clone()
+	unflagged.Flags = nil
+	converted, err = unflagged.ConvertUnits("si")
+	require.NoError(t, err)
+	require.Equal(t, "si", converted.Flags.Units)
+	require.InDelta(t, si.Currently.Temperature, converted.Currently.Temperature, 0.01)
+}
+
+func TestConvertUnitsKeepsMissingValues(t *testing.T) {
+	var forecast models.ForecastResponse
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"currently": {"time": 1700000000, "temperature": -999, "windSpeed": 10},
+		"flags": {"units": "si"}
+	}`), &forecast))
+
+	converted, err := forecast.ConvertUnits("ca")
+	require.NoError(t, err)
+	require.Equal(t, models.ValueSentinel, converted.Currently.State("temperature"))
+	require.Equal(t, models.ValueAbsent, converted.Currently.State("dewPoint"))
+	require.InDelta(t, 36, converted.Currently.WindSpeed, 1e-9)
+}
@@ -0,0 +1,257 @@
+// Package nowcast analyzes the minutely block: when precipitation starts and stops, how heavy
+// it gets and how sure the forecast is, and describes it in a sentence.
+package nowcast
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Intensity is a precipitation intensity class
+type Intensity int
+
+const (
+	IntensityNone Intensity = iota
+	IntensityLight
+	IntensityModerate
+	IntensityHeavy
+)
+
+func (i Intensity) String() string {
+	switch i {
+	case IntensityLight:
+		return "light"
+	case IntensityModerate:
+		return "moderate"
+	case IntensityHeavy:
+		return "heavy"
+	default:
+		return "none"
+	}
+}
+
+// Rain rate classes in mm/h, applied to the liquid equivalent of any precipitation type
+const (
+	moderateRate = 2.5
+	heavyRate    = 7.6
+)
+
+// Classify returns the intensity class of a precipitation intensity in the units system's
+// precipitation intensity unit. Intensities below the threshold in mm/h are IntensityNone.
+func Classify(intensity float64, units string, threshold float64) (Intensity, error) {
+	rate, err := models.ConvertValue(models.QuantityPrecipIntensity, intensity, units, models.UnitsSI)
+	if err != nil {
+		return IntensityNone, err
+	}
+	switch {
+	case rate >= heavyRate:
+		return IntensityHeavy, nil
+	case rate >= moderateRate:
+		return IntensityModerate, nil
+	case rate >= threshold:
+		return IntensityLight, nil
+	default:
+		return IntensityNone, nil
+	}
+}
+
+const (
+	defaultThreshold      = 0.05 // mm/h
+	defaultMinProbability = 0.5
+)
+
+type config struct {
+	threshold      float64
+	minProbability float64
+}
+
+// Option configures the analysis
+type Option func(*config)
+
+// WithThreshold sets the intensity in mm/h from which a minute counts as wet. The default is 0.05 mm/h.
+func WithThreshold(threshold float64) Option {
+	return func(c *config) {
+		c.threshold = threshold
+	}
+}
+
+// WithMinProbability sets the precipitation probability from which a minute counts as wet. The default is 0.5.
+func WithMinProbability(probability float64) Option {
+	return func(c *config) {
+		c.minProbability = probability
+	}
+}
+
+// Minute is one analyzed minutely point. Intensities are in the forecast's units; Low and High
+// are the intensity minus and plus PrecipIntensityError, the forecast's confidence band.
+type Minute struct {
+	Time        time.Time
+	Intensity   float64
+	Low         float64
+	High        float64
+	Probability float64
+	Class       Intensity
+	Wet         bool
+	// Certain is false when the confidence band straddles the wet threshold
+	Certain bool
+}
+
+// Nowcast is the analysis of a minutely block. Times are zero when the event is not in the block.
+type Nowcast struct {
+	Start      time.Time
+	Minutes    []Minute
+	PrecipType string
+
+	// Precipitating reports whether the first minute is wet
+	Precipitating bool
+	// Onset is the first wet minute after a dry start and Cessation the first dry minute after the
+	// first wet spell. EarliestOnset is the first minute that may be wet given the confidence band,
+	// and LatestCessation the first minute after the wet spell that is surely dry.
+	Onset           time.Time
+	Cessation       time.Time
+	EarliestOnset   time.Time
+	LatestCessation time.Time
+
+	Peak          time.Time
+	PeakIntensity float64
+	PeakClass     Intensity
+
+	// Confidence is the fraction of minutes whose confidence band lies entirely on one side of the wet threshold
+	Confidence float64
+}
+
+// Analyze analyzes the forecast's minutely block in its own units system and timezone
+func Analyze(forecast *models.ForecastResponse, opts ...Option) (*Nowcast, error) {
+	if forecast.Minutely == nil || len(forecast.Minutely.Data) == 0 {
+		return nil, models.ErrNoMinutely
+	}
+	return AnalyzeBlock(forecast.Minutely, forecast.Units(), forecast.Location(), opts...)
+}
+
+// AnalyzeBlock analyzes a minutely block whose values are in the given units system
+func AnalyzeBlock(block *models.DataBlock, units string, loc *time.Location, opts ...Option) (*Nowcast, error) {
+	if block == nil || len(block.Data) == 0 {
+		return nil, models.ErrNoMinutely
+	}
+	c := &config{threshold: defaultThreshold, minProbability: defaultMinProbability}
+	for _, opt := range opts {
+		opt(c)
+	}
+	threshold, err := models.ConvertValue(models.QuantityPrecipIntensity, c.threshold, models.UnitsSI, units)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Nowcast{Start: time.Unix(block.Data[0].Time, 0).In(loc), Minutes: make([]Minute, len(block.Data))}
+	certain := 0
+	for i := range block.Data {
+		p := &block.Data[i]
+		intensity, _ := p.Value("precipIntensity")
+		spread, _ := p.Value("precipIntensityError")
+		probability, hasProbability := p.Value("precipProbability")
+		likely := !hasProbability || probability >= c.minProbability
+
+		m := Minute{
+			Time:        time.Unix(p.Time, 0).In(loc),
+			Intensity:   intensity,
+			Low:         max(0, intensity-spread),
+			High:        intensity + spread,
+			Probability: probability,
+		}
+		m.Wet = likely && m.Intensity >= threshold
+		m.Certain = !likely || m.Low >= threshold || m.High < threshold
+		if m.Wet {
+			if m.Class, err = Classify(intensity, units, c.threshold); err != nil {
+				return nil, err
+			}
+			if n.PrecipType == "" && p.PrecipType != "" && p.PrecipType != "none" {
+				n.PrecipType = p.PrecipType
+			}
+		}
+		if m.Certain {
+			certain++
+		}
+		if m.Wet && m.Intensity > n.PeakIntensity {
+			n.Peak, n.PeakIntensity, n.PeakClass = m.Time, m.Intensity, m.Class
+		}
+		n.Minutes[i] = m
+	}
+	n.Confidence = float64(certain) / float64(len(n.Minutes))
+	n.Precipitating = n.Minutes[0].Wet
+
+	possiblyWet := func(m Minute) bool { return m.Wet || (!m.Certain && m.High >= threshold) }
+	if first := find(n.Minutes, 0, func(m Minute) bool { return m.Wet }); first >= 0 {
+		if first > 0 {
+			n.Onset = n.Minutes[first].Time
+		}
+		if last := find(n.Minutes, first, func(m Minute) bool { return !m.Wet }); last >= 0 {
+			n.Cessation = n.Minutes[last].Time
+		}
+		if last := find(n.Minutes, first, func(m Minute) bool { return !possiblyWet(m) }); last >= 0 {
+			n.LatestCessation = n.Minutes[last].Time
+		}
+	}
+	if possible := find(n.Minutes, 0, possiblyWet); possible >= 0 && !n.Precipitating {
+		n.EarliestOnset = n.Minutes[possible].Time
+	}
+	return n, nil
+}
+
+// find returns the index of the first minute from index from that matches, or -1
+func find(minutes []Minute, from int, match func(Minute) bool) int {
+	for i := from; i < len(minutes); i++ {
+		if match(minutes[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Dry reports whether no minute of the block is wet
+func (n *Nowcast) Dry() bool {
+	return !n.Precipitating && n.Onset.IsZero()
+}
+
+// Summary describes the nowcast in a sentence such as "Light rain starting in 12 min, stopping in 40 min."
+// Forecasts with a confidence below 0.75 are described as possible.
+func (n *Nowcast) Summary() string {
+	window := n.minutesFromStart(n.Minutes[len(n.Minutes)-1].Time) + 1
+	if n.Dry() {
+		if window >= 60 {
+			return "No precipitation for the hour."
+		}
+		return fmt.Sprintf("No precipitation for the next %d min.", window)
+	}
+
+	kind := n.PrecipType
+	if kind == "" {
+		kind = "precipitation"
+	}
+	phrase := n.PeakClass.String() + " " + kind
+	if n.Confidence < 0.75 {
+		phrase = "possible " + phrase
+	}
+
+	var sentence string
+	switch {
+	case n.Precipitating && n.Cessation.IsZero() && window >= 60:
+		sentence = phrase + " for the hour"
+	case n.Precipitating && n.Cessation.IsZero():
+		sentence = fmt.Sprintf("%s for the next %d min", phrase, window)
+	case n.Precipitating:
+		sentence = fmt.Sprintf("%s stopping in %d min", phrase, n.minutesFromStart(n.Cessation))
+	case n.Cessation.IsZero():
+		sentence = fmt.Sprintf("%s starting in %d min", phrase, n.minutesFromStart(n.Onset))
+	default:
+		sentence = fmt.Sprintf("%s starting in %d min, stopping in %d min", phrase,
+			n.minutesFromStart(n.Onset), n.minutesFromStart(n.Cessation))
+	}
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+func (n *Nowcast) minutesFromStart(t time.Time) int {
+	return int(t.Sub(n.Start) / time.Minute)
+}
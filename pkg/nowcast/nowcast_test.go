@@ -0,0 +1,164 @@
+package nowcast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/nowcast"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 5, 3, 14, 0, 0, 0, time.UTC)
+
+// minutely builds a 61-minute block from per-minute intensities in mm/h, each with the given error
+func minutely(intensity func(minute int) float64, spread float64) *models.DataBlock {
+	block := &models.DataBlock{}
+	for i := 0; i <= 60; i++ {
+		value := intensity(i)
+		p := models.DataPoint{
+			Time:                 start.Add(time.Duration(i) * time.Minute).Unix(),
+			PrecipIntensity:      value,
+			PrecipIntensityError: spread,
+			PrecipProbability:    1,
+			PrecipType:           "none",
+		}
+		if value > 0 {
+			p.PrecipType = "rain"
+		} else {
+			p.PrecipProbability = 0
+		}
+		block.Data = append(block.Data, p)
+	}
+	return block
+}
+
+func between(from, to int, value float64) func(int) float64 {
+	return func(minute int) float64 {
+		if minute >= from && minute < to {
+			return value
+		}
+		return 0
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		intensity float64
+		units     string
+		want      nowcast.Intensity
+	}{
+		{0.01, "si", nowcast.IntensityNone},
+		{0.5, "si", nowcast.IntensityLight},
+		{3, "ca", nowcast.IntensityModerate},
+		{10, "uk", nowcast.IntensityHeavy},
+		{0.05, "us", nowcast.IntensityLight},
+		{0.2, "us", nowcast.IntensityModerate},
+		{0.35, "us", nowcast.IntensityHeavy},
+	}
+	for _, tt := range tests {
+		got, err := nowcast.Classify(tt.intensity, tt.units, 0.05)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "%v %s", tt.intensity, tt.units)
+	}
+	_, err := nowcast.Classify(1, "metric", 0.05)
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestOnsetAndCessation(t *testing.T) {
+	n, err := nowcast.AnalyzeBlock(minutely(between(12, 40, 1.2), 0), "si", time.UTC)
+	require.NoError(t, err)
+	require.False(t, n.Precipitating)
+	require.Equal(t, start.Add(12*time.Minute), n.Onset)
+	require.Equal(t, start.Add(40*time.Minute), n.Cessation)
+	require.Equal(t, nowcast.IntensityLight, n.PeakClass)
+	require.Equal(t, "rain", n.PrecipType)
+	require.Equal(t, 1.0, n.Confidence)
+	require.Equal(t, "Light rain starting in 12 min, stopping in 40 min.", n.Summary())
+
+	n, err = nowcast.AnalyzeBlock(minutely(between(0, 25, 4), 0), "si", time.UTC)
+	require.NoError(t, err)
+	require.True(t, n.Precipitating)
+	require.True(t, n.Onset.IsZero())
+	require.Equal(t, "Moderate rain stopping in 25 min.", n.Summary())
+
+	n, err = nowcast.AnalyzeBlock(minutely(between(0, 61, 9), 0), "si", time.UTC)
+	require.NoError(t, err)
+	require.True(t, n.Cessation.IsZero())
+	require.Equal(t, "Heavy rain for the hour.", n.Summary())
+
+	n, err = nowcast.AnalyzeBlock(minutely(between(30, 61, 0.3), 0), "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Light rain starting in 30 min.", n.Summary())
+
+	n, err = nowcast.AnalyzeBlock(minutely(between(0, 0, 0), 0), "si", time.UTC)
+	require.NoError(t, err)
+	require.True(t, n.Dry())
+	require.Equal(t, "No precipitation for the hour.", n.Summary())
+}
+
+func TestPeak(t *testing.T) {
+	block := minutely(func(minute int) float64 {
+		if minute < 10 || minute >= 50 {
+			return 0
+		}
+		return 10 - float64(minute-30)*float64(minute-30)/50
+	}, 0)
+	n, err := nowcast.AnalyzeBlock(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(30*time.Minute), n.Peak)
+	require.Equal(t, 10.0, n.PeakIntensity)
+	require.Equal(t, nowcast.IntensityHeavy, n.PeakClass)
+}
+
+func TestConfidenceBand(t *testing.T) {
+	// Light rain from minute 20 to 30 with an error wide enough to straddle the threshold on either side
+	block := minutely(func(minute int) float64 {
+		switch {
+		case minute >= 20 && minute < 30:
+			return 0.1
+		case minute >= 15 && minute < 35:
+			return 0.03
+		}
+		return 0
+	}, 0.08)
+	n, err := nowcast.AnalyzeBlock(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(20*time.Minute), n.Onset)
+	require.Equal(t, start.Add(15*time.Minute), n.EarliestOnset)
+	require.Equal(t, start.Add(30*time.Minute), n.Cessation)
+	require.Equal(t, start.Add(35*time.Minute), n.LatestCessation)
+	require.InDelta(t, 41.0/61, n.Confidence, 1e-9)
+	require.Equal(t, "Possible light rain starting in 20 min, stopping in 30 min.", n.Summary())
+}
+
+func TestProbabilityAndOptions(t *testing.T) {
+	block := minutely(between(0, 61, 0.5), 0)
+	for i := range block.Data {
+		block.Data[i].PrecipProbability = 0.3
+	}
+	n, err := nowcast.AnalyzeBlock(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.True(t, n.Dry())
+
+	n, err = nowcast.AnalyzeBlock(block, "si", time.UTC, nowcast.WithMinProbability(0.2), nowcast.WithThreshold(1))
+	require.NoError(t, err)
+	require.True(t, n.Dry())
+
+	n, err = nowcast.AnalyzeBlock(block, "si", time.UTC, nowcast.WithMinProbability(0.2))
+	require.NoError(t, err)
+	require.True(t, n.Precipitating)
+}
+
+func TestAnalyze(t *testing.T) {
+	forecast := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("us"))
+	n, err := nowcast.Analyze(forecast)
+	require.NoError(t, err)
+	require.Len(t, n.Minutes, len(forecast.Minutely.Data))
+	require.Equal(t, forecast.Location(), n.Start.Location())
+	require.NotEmpty(t, n.Summary())
+
+	_, err = nowcast.Analyze(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoMinutely)
+}
@@ -13,11 +13,18 @@ type CacheItem struct {
 type Cache struct {
 	items map[string]CacheItem
 	mu    sync.RWMutex
+	clock Clock
 }
 
 func NewCache() *Cache {
+	return NewCacheWithClock(SystemClock)
+}
+
+// NewCacheWithClock creates a cache whose items expire according to the given clock
+func NewCacheWithClock(clock Clock) *Cache {
 	return &Cache{
 		items: make(map[string]CacheItem),
+		clock: clock,
 	}
 }
 
@@ -26,18 +33,18 @@ func (c *Cache) Set(key string, value interface{}, expiration time.Duration) {
 	defer c.mu.Unlock()
 	c.items[key] = CacheItem{
 		Value:      value,
-		Expiration: timeNow().Add(expiration),
+		Expiration: c.clock.Now().Add(expiration),
 	}
 }
 
 func (c *Cache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	item, found := c.items[key]
 	if !found {
 		return nil, false
 	}
-	if timeNow().After(item.Expiration) {
+	if c.clock.Now().After(item.Expiration) {
 		delete(c.items, key)
 		return nil, false
 	}
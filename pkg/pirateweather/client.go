@@ -8,10 +8,16 @@ import (
 
 var timeNow = time.Now
 
+// SetTimeNow overrides the time returned by SystemClock.
+//
+// Deprecated: the override is global and racy under t.Parallel(); configure a Clock with WithClock instead.
 func SetTimeNow(f func() time.Time) {
 	timeNow = f
 }
 
+// ResetTimeNow restores the time returned by SystemClock.
+//
+// Deprecated: configure a Clock with WithClock instead.
 func ResetTimeNow() {
 	timeNow = time.Now
 }
@@ -27,17 +33,45 @@ type Client struct {
 	BaseURL     string
 	RateLimiter *RateLimiter
 	Cache       *Cache
+	// clock is set only through WithClock, so the cache and rate limiter built with the client share it
+	clock Clock
+}
+
+// ClientOption represents an option for NewClient
+type ClientOption func(*Client)
+
+// WithClock sets the clock driving the client's retries, cache expiry and rate limiter. A nil clock is
+// ignored, leaving SystemClock.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
 }
 
 // NewClient creates a new Pirate Weather API client with the given API key
-func NewClient(apiKey string) *Client {
-	return &Client{
+func NewClient(apiKey string, options ...ClientOption) *Client {
+	c := &Client{
 		APIKey: apiKey,
 		HTTPClient: &http.Client{
 			Timeout: time.Second * 10,
 		},
-		BaseURL:     baseURL,
-		RateLimiter: NewRateLimiter(10000), // Default limit of 10000 requests per month
-		Cache:       NewCache(),
+		BaseURL: baseURL,
+		clock:   SystemClock,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	c.RateLimiter = NewRateLimiterWithClock(10000, c.clock) // Default limit of 10000 requests per month
+	c.Cache = NewCacheWithClock(c.clock)
+	return c
+}
+
+// Clock returns the client's clock, falling back to SystemClock for clients built without NewClient
+func (c *Client) Clock() Clock {
+	if c.clock == nil {
+		return SystemClock
 	}
+	return c.clock
 }
@@ -2,6 +2,7 @@ package pirateweather_test
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -41,3 +42,21 @@ func TestClientWithCustomBaseURL(t *testing.T) {
 
 	require.Equal(t, customBaseURL, client.BaseURL)
 }
+
+func TestClientWrapsUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	client := pirateweather.NewClient("test-api-key")
+	client.BaseURL = server.URL
+
+	_, err := client.Forecast(45.42, -75.69)
+	require.ErrorIs(t, err, pirateweather.ErrStatus)
+	require.Contains(t, err.Error(), "418")
+
+	_, err = client.TimeMachine(45.42, -75.69, time.Unix(1700000000, 0))
+	require.ErrorIs(t, err, pirateweather.ErrStatus)
+	require.Contains(t, err.Error(), "418")
+}
@@ -0,0 +1,114 @@
+package pirateweather_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweather"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1620000000, 0)
+	clock := pirateweather.NewFakeClock(start)
+	require.Equal(t, start, clock.Now())
+
+	early := clock.After(time.Minute)
+	late := clock.After(time.Hour)
+	require.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-early:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	require.Equal(t, start.Add(time.Minute), <-early)
+	require.Equal(t, 1, clock.Waiters())
+
+	clock.Set(start.Add(2 * time.Hour))
+	require.Equal(t, start.Add(2*time.Hour), <-late)
+	require.Zero(t, clock.Waiters())
+
+	select {
+	case <-clock.After(0):
+	default:
+		t.Fatal("zero duration timer should fire immediately")
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	t.Parallel()
+
+	clock := pirateweather.NewFakeClock(time.Unix(1620000000, 0))
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Hour)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	<-done
+}
+
+func TestCacheExpiresWithClock(t *testing.T) {
+	t.Parallel()
+
+	clock := pirateweather.NewFakeClock(time.Unix(1620000000, 0))
+	cache := pirateweather.NewCacheWithClock(clock)
+
+	cache.Set("key", "value", time.Minute)
+	value, found := cache.Get("key")
+	require.True(t, found)
+	require.Equal(t, "value", value)
+
+	clock.Advance(time.Minute + time.Second)
+	_, found = cache.Get("key")
+	require.False(t, found)
+}
+
+func TestMockClientCacheUsesClock(t *testing.T) {
+	t.Parallel()
+
+	clock := pirateweather.NewFakeClock(time.Unix(1620000000, 0))
+	mockClient := &pirateweather.MockClient{Clock: clock}
+
+	forecast, err := mockClient.Forecast(45.42, -75.69)
+	require.NoError(t, err)
+	require.Equal(t, int64(1620000000), forecast.Currently.Time)
+
+	clock.Advance(16 * time.Minute)
+	_, err = mockClient.Forecast(45.42, -75.69)
+	require.NoError(t, err)
+
+	calls := mockClient.Calls()
+	require.Len(t, calls, 2)
+	require.False(t, calls[1].Cached)
+}
+
+func TestClientWithClock(t *testing.T) {
+	t.Parallel()
+
+	clock := pirateweather.NewFakeClock(time.Unix(1620000000, 0))
+	client := pirateweather.NewClient("test-api-key", pirateweather.WithClock(clock))
+
+	require.Equal(t, clock, client.Clock())
+	require.Equal(t, pirateweather.SystemClock, (&pirateweather.Client{}).Clock())
+
+	// A nil clock keeps the system clock, so the cache and rate limiter still work
+	fallback := pirateweather.NewClient("test-api-key", pirateweather.WithClock(nil))
+	require.Equal(t, pirateweather.SystemClock, fallback.Clock())
+	fallback.Cache.Set("forecast", &models.ForecastResponse{}, time.Hour)
+	require.True(t, fallback.RateLimiter.Allow())
+
+	client.Cache.Set("forecast", &models.ForecastResponse{}, time.Hour)
+	clock.Advance(2 * time.Hour)
+	_, found := client.Cache.Get("forecast")
+	require.False(t, found)
+}
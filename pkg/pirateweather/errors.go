@@ -1,32 +1,111 @@
 package pirateweather
 
 import (
+	"errors"
 	"fmt"
 )
 
+// Sentinel errors of the client. Failed requests wrap them, so callers can check them with errors.Is.
+var (
+	ErrCreateRequest = errors.New("error creating request")
+	ErrRequest       = errors.New("error making request")
+	ErrDecode        = errors.New("error decoding response")
+	ErrRateLimited   = errors.New("rate limit exceeded")
+	ErrBadRequest    = errors.New("bad request: invalid latitude or longitude")
+	ErrUnauthorized  = errors.New("unauthorized: invalid API key or insufficient permissions")
+	ErrNotFound      = errors.New("not found: invalid route or missing latitude/longitude")
+	ErrQuotaExceeded = errors.New("rate limit exceeded: API key has hit the quota for the month")
+	ErrServerError   = errors.New("internal server error")
+	ErrStatus        = errors.New("API request failed with status code")
+)
+
 // APIError represents an error returned by the Pirate Weather API
 type APIError struct {
 	Message string
+	Err     error // the cause, if known
 }
 
 func (e *APIError) Error() string {
 	return fmt.Sprintf("API Error: %s", e.Message)
 }
 
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// MessageFormat returns the format and arguments i18n.Error translates: the cause, or the message without one
+func (e *APIError) MessageFormat() (string, []any) {
+	if e.Err != nil {
+		return "API Error: %v", []any{e.Err}
+	}
+	return "API Error: %v", []any{e.Message}
+}
+
 // RateLimitError represents a rate limit error
 type RateLimitError struct {
 	Message string
+	Err     error // the cause, if known
 }
 
 func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("Rate Limit Error: %s", e.Message)
 }
 
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// MessageFormat returns the format and arguments i18n.Error translates: the cause, or the message without one
+func (e *RateLimitError) MessageFormat() (string, []any) {
+	if e.Err != nil {
+		return "Rate Limit Error: %v", []any{e.Err}
+	}
+	return "Rate Limit Error: %v", []any{e.Message}
+}
+
 // JSONError represents an error that occurred while parsing JSON
 type JSONError struct {
 	Message string
+	Err     error // the cause, if known
 }
 
 func (e *JSONError) Error() string {
 	return fmt.Sprintf("JSON Error: %s", e.Message)
 }
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// MessageFormat returns the format and arguments i18n.Error translates: the cause, or the message without one
+func (e *JSONError) MessageFormat() (string, []any) {
+	if e.Err != nil {
+		return "JSON Error: %v", []any{e.Err}
+	}
+	return "JSON Error: %v", []any{e.Message}
+}
+
+// RetryError is returned when the API still fails after the client's retries
+type RetryError struct {
+	Retries int
+	Err     error // the failure of the last attempt, if known
+}
+
+func (e *RetryError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("API request failed after %d retries", e.Retries)
+	}
+	return fmt.Sprintf("API request failed after %d retries: %v", e.Retries, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// MessageFormat returns the format and arguments i18n.Error translates
+func (e *RetryError) MessageFormat() (string, []any) {
+	if e.Err == nil {
+		return "API request failed after %d retries", []any{e.Retries}
+	}
+	return "API request failed after %d retries: %v", []any{e.Retries, e.Err}
+}
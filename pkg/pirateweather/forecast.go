@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -19,7 +20,7 @@ const (
 // Forecast retrieves the weather forecast for a given location
 // It takes latitude and longitude as parameters, along with optional ForecastOptions
 func (c *Client) Forecast(latitude, longitude float64, options ...ForecastOption) (*models.ForecastResponse, error) {
-	cacheKey := fmt.Sprintf("forecast:%f:%f:%v", latitude, longitude, options)
+	cacheKey := forecastCacheKey(latitude, longitude, options)
 	if cachedForecast, found := c.Cache.Get(cacheKey); found {
 		return cachedForecast.(*models.ForecastResponse), nil
 	}
@@ -28,7 +29,7 @@ func (c *Client) Forecast(latitude, longitude float64, options ...ForecastOption
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
 	}
 
 	// Apply all provided options to the request
@@ -43,13 +44,14 @@ func (c *Client) Forecast(latitude, longitude float64, options ...ForecastOption
 	for i := 0; i < maxRetries; i++ {
 		if !c.RateLimiter.Allow() {
 			return nil, &RateLimitError{
-				Message: "rate limit exceeded",
+				Message: ErrRateLimited.Error(),
+				Err:     ErrRateLimited,
 			}
 		}
 
 		resp, err = c.HTTPClient.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("error making request: %w", err)
+			return nil, fmt.Errorf("%w: %w", ErrRequest, err)
 		}
 		defer resp.Body.Close()
 
@@ -57,34 +59,38 @@ func (c *Client) Forecast(latitude, longitude float64, options ...ForecastOption
 		switch resp.StatusCode {
 		case http.StatusOK:
 			if err := json.NewDecoder(resp.Body).Decode(&forecast); err != nil {
+				err = fmt.Errorf("%w: %w", ErrDecode, err)
 				return nil, &JSONError{
-					Message: fmt.Sprintf("error decoding response: %v", err),
+					Message: err.Error(),
+					Err:     err,
 				}
 			}
 			c.updateRateLimiter(resp.Header)
 			c.Cache.Set(cacheKey, &forecast, time.Hour) // Cache for 1 hour
 			return &forecast, nil
 		case http.StatusBadRequest:
-			return nil, fmt.Errorf("bad request: invalid latitude or longitude")
+			return nil, ErrBadRequest
 		case http.StatusUnauthorized:
-			return nil, fmt.Errorf("unauthorized: invalid API key or insufficient permissions")
+			return nil, ErrUnauthorized
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("not found: invalid route or missing latitude/longitude")
+			return nil, ErrNotFound
 		case http.StatusTooManyRequests:
-			return nil, fmt.Errorf("rate limit exceeded: API key has hit the quota for the month")
+			return nil, ErrQuotaExceeded
 		case http.StatusInternalServerError:
 			if i == maxRetries-1 {
-				return nil, fmt.Errorf("API request failed after %d retries: internal server error", maxRetries)
+				return nil, &RetryError{Retries: maxRetries, Err: ErrServerError}
 			}
-			time.Sleep(retryDelay)
+			c.Clock().Sleep(retryDelay)
 		default:
+			err := fmt.Errorf("%w: %d", ErrStatus, resp.StatusCode)
 			return nil, &APIError{
-				Message: fmt.Sprintf("API request failed with unexpected status code: %d", resp.StatusCode),
+				Message: err.Error(),
+				Err:     err,
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("API request failed after %d retries", maxRetries)
+	return nil, &RetryError{Retries: maxRetries}
 }
 
 // updateRateLimiter updates the rate limiter based on the response headers
@@ -99,6 +105,48 @@ func (c *Client) updateRateLimiter(headers http.Header) {
 // ForecastOption represents an option for the Forecast method
 type ForecastOption func(*http.Request)
 
+// RequestOptions holds the query parameters set by a list of ForecastOptions
+type RequestOptions struct {
+	Units    string
+	Exclude  []string
+	Extend   string
+	Version  int
+	Language string
+	Query    url.Values
+}
+
+// DecodeOptions applies the options to an empty request and returns the resulting query parameters
+func DecodeOptions(options ...ForecastOption) RequestOptions {
+	req := &http.Request{URL: &url.URL{}}
+	for _, option := range options {
+		option(req)
+	}
+
+	query := req.URL.Query()
+	decoded := RequestOptions{
+		Units:    query.Get("units"),
+		Extend:   query.Get("extend"),
+		Language: query.Get("lang"),
+		Query:    query,
+	}
+	if exclude := query.Get("exclude"); exclude != "" {
+		decoded.Exclude = strings.Split(exclude, ",")
+	}
+	decoded.Version, _ = strconv.Atoi(query.Get("version"))
+	return decoded
+}
+
+// forecastCacheKey builds the cache key of a forecast request from its decoded options,
+// so that e.g. WithUnits("si") and WithUnits("us") are cached separately
+func forecastCacheKey(latitude, longitude float64, options []ForecastOption) string {
+	return fmt.Sprintf("forecast:%f:%f:%s", latitude, longitude, DecodeOptions(options...).Query.Encode())
+}
+
+// timeMachineCacheKey builds the cache key of a time machine request from its decoded options
+func timeMachineCacheKey(latitude, longitude float64, timestamp time.Time, options []ForecastOption) string {
+	return fmt.Sprintf("timemachine:%f:%f:%d:%s", latitude, longitude, timestamp.Unix(), DecodeOptions(options...).Query.Encode())
+}
+
 // WithUnits sets the units for the forecast request
 func WithUnits(units string) ForecastOption {
 	return func(req *http.Request) {
@@ -134,3 +182,12 @@ func WithVersion(version int) ForecastOption {
 		req.URL.RawQuery = q.Encode()
 	}
 }
+
+// WithLanguage sets the language of the summaries returned by the API, e.g. "fr" or "zh-tw"
+func WithLanguage(language string) ForecastOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		q.Add("lang", language)
+		req.URL.RawQuery = q.Encode()
+	}
+}
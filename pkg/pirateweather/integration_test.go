@@ -0,0 +1,158 @@
+package pirateweather_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweather"
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweathertest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientForecastAgainstServer(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	forecast, err := client.Forecast(45.42, -75.69,
+		pirateweather.WithUnits("si"),
+		pirateweather.WithExclude([]string{"minutely"}),
+		pirateweather.WithExtend("hourly"),
+		pirateweather.WithVersion(2),
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, 45.42, forecast.Latitude)
+	require.Equal(t, -75.69, forecast.Longitude)
+	require.Nil(t, forecast.Minutely)
+	require.Len(t, forecast.Hourly.Data, 168)
+	require.Equal(t, "si", forecast.Flags.Units)
+	require.NotZero(t, forecast.Daily.Data[0].DawnTime)
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, "si", requests[0].Query["units"])
+	require.Equal(t, "minutely", requests[0].Query["exclude"])
+	require.Equal(t, "hourly", requests[0].Query["extend"])
+	require.Equal(t, "2", requests[0].Query["version"])
+
+	// A second request is served from the cache
+	_, err = client.Forecast(45.42, -75.69,
+		pirateweather.WithUnits("si"),
+		pirateweather.WithExclude([]string{"minutely"}),
+		pirateweather.WithExtend("hourly"),
+		pirateweather.WithVersion(2),
+	)
+	require.NoError(t, err)
+	require.Len(t, server.Requests(), 1)
+}
+
+func TestClientTimeMachineAgainstServer(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	forecast, err := server.Client().TimeMachine(45.42, -75.69, time.Unix(1620000000, 0))
+	require.NoError(t, err)
+	require.Equal(t, int64(1620000000), forecast.Currently.Time)
+	require.Len(t, forecast.Hourly.Data, 24)
+	require.Equal(t, "us", forecast.Flags.Units)
+}
+
+func TestClientUnauthorized(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	client.APIKey = "wrong-key"
+
+	_, err := client.Forecast(45.42, -75.69)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unauthorized")
+	require.ErrorIs(t, err, pirateweather.ErrUnauthorized)
+}
+
+func TestClientRateLimitedByServer(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	server.RateLimited(10000, 0, time.Now().Add(time.Hour))
+
+	_, err := server.Client().Forecast(45.42, -75.69)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "rate limit exceeded")
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	server.ServerErrors(1)
+
+	clock := pirateweather.NewFakeClock(time.Now())
+	client := server.Client(pirateweather.WithClock(clock))
+
+	type result struct {
+		forecast *models.ForecastResponse
+		err      error
+	}
+	results := make(chan result, 1)
+	go func() {
+		forecast, err := client.Forecast(45.42, -75.69)
+		results <- result{forecast, err}
+	}()
+
+	// The client sleeps on the clock before retrying the 500
+	clock.BlockUntil(1)
+	require.Len(t, server.Requests(), 1)
+	clock.Advance(2 * time.Second)
+
+	res := <-results
+	require.NoError(t, res.err)
+	require.NotNil(t, res.forecast.Currently)
+	require.Len(t, server.Requests(), 2)
+}
+
+func TestClientMalformedJSON(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	server.MalformedJSON()
+
+	_, err := server.Client().Forecast(45.42, -75.69)
+	require.Error(t, err)
+
+	var jsonErr *pirateweather.JSONError
+	require.ErrorAs(t, err, &jsonErr)
+}
+
+func TestClientSlowResponse(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	server.Slow(500 * time.Millisecond)
+
+	client := server.Client()
+	client.HTTPClient = &http.Client{Timeout: 50 * time.Millisecond}
+
+	_, err := client.Forecast(45.42, -75.69)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error making request")
+	require.ErrorIs(t, err, pirateweather.ErrRequest)
+}
+
+func TestClientCacheSeparatesUnits(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	si, err := client.Forecast(45.42, -75.69, pirateweather.WithUnits("si"))
+	require.NoError(t, err)
+	us, err := client.Forecast(45.42, -75.69, pirateweather.WithUnits("us"))
+	require.NoError(t, err)
+
+	require.Equal(t, "si", si.Flags.Units)
+	require.Equal(t, "us", us.Flags.Units)
+	require.Len(t, server.Requests(), 2)
+}
@@ -3,46 +3,172 @@ package pirateweather
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	mocktime "time"
 
 	"github.com/jdotcurs/pirateweather-go/pkg/models"
 )
 
+const (
+	// MethodForecast identifies calls to MockClient.Forecast
+	MethodForecast = "Forecast"
+	// MethodTimeMachine identifies calls to MockClient.TimeMachine
+	MethodTimeMachine = "TimeMachine"
+)
+
+// MockResponse is a scripted result returned by the MockClient
+type MockResponse struct {
+	Forecast *models.ForecastResponse
+	Err      error
+}
+
+// Call records a single call made to the MockClient
+type Call struct {
+	Method    string
+	Latitude  float64
+	Longitude float64
+	Time      mocktime.Time
+	Options   RequestOptions
+	Cached    bool
+}
+
+// TestingT is the subset of testing.TB used by the MockClient assertion helpers
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// MockClient is a test double for Client.
+//
+// Responses are resolved in order from the scripted sequences registered with OnForecast
+// and OnTimeMachine, the ForecastFunc and TimeMachineFunc fields, and finally a canned
+// forecast from DefaultForecast. Scripted responses are served in order and bypass the cache;
+// the other successful responses are cached like the real client unless DisableCache is set,
+// and the cache expires according to Clock.
 type MockClient struct {
 	ForecastFunc          func(latitude, longitude float64, options ...ForecastOption) (*models.ForecastResponse, error)
 	TimeMachineFunc       func(latitude, longitude float64, time mocktime.Time, options ...ForecastOption) (*models.ForecastResponse, error)
 	UpdateRateLimiterFunc func(headers http.Header)
 	Cache                 *Cache
+	DisableCache          bool
+	Clock                 Clock
+
+	mu          sync.Mutex
+	calls       []Call
+	forecasts   map[string][]MockResponse
+	timeMachine map[string][]MockResponse
 }
 
-func (m *MockClient) initCache() {
+// cache returns the cache, creating it on first use. Cache is read under mu so Reset can clear it
+// while requests are in flight.
+func (m *MockClient) cache() *Cache {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.Cache == nil {
-		m.Cache = NewCache()
+		m.Cache = NewCacheWithClock(m.clock())
+	}
+	return m.Cache
+}
+
+func (m *MockClient) clock() Clock {
+	if m.Clock == nil {
+		return SystemClock
+	}
+	return m.Clock
+}
+
+// OnForecast scripts the responses returned by successive Forecast calls for a location.
+// Once the sequence is exhausted the last response keeps being returned.
+func (m *MockClient) OnForecast(latitude, longitude float64, responses ...MockResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.forecasts == nil {
+		m.forecasts = make(map[string][]MockResponse)
 	}
+	key := locationKey(latitude, longitude)
+	m.forecasts[key] = append(m.forecasts[key], responses...)
+}
+
+// OnTimeMachine scripts the responses returned by successive TimeMachine calls for a location.
+// Once the sequence is exhausted the last response keeps being returned.
+func (m *MockClient) OnTimeMachine(latitude, longitude float64, responses ...MockResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.timeMachine == nil {
+		m.timeMachine = make(map[string][]MockResponse)
+	}
+	key := locationKey(latitude, longitude)
+	m.timeMachine[key] = append(m.timeMachine[key], responses...)
 }
 
 func (m *MockClient) Forecast(latitude, longitude float64, options ...ForecastOption) (*models.ForecastResponse, error) {
-	m.initCache()
-	cacheKey := fmt.Sprintf("forecast:%f:%f:%v", latitude, longitude, options)
-	if cachedForecast, found := m.Cache.Get(cacheKey); found {
-		return cachedForecast.(*models.ForecastResponse), nil
+	call := Call{
+		Method:    MethodForecast,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Options:   DecodeOptions(options...),
+	}
+
+	if response, scripted := m.next(MethodForecast, latitude, longitude); scripted {
+		m.record(call)
+		return response.Forecast, response.Err
 	}
-	forecast, err := m.ForecastFunc(latitude, longitude, options...)
+
+	cacheKey := forecastCacheKey(latitude, longitude, options)
+	if forecast, found := m.cached(cacheKey); found {
+		call.Cached = true
+		m.record(call)
+		return forecast, nil
+	}
+	m.record(call)
+
+	var forecast *models.ForecastResponse
+	var err error
+	if m.ForecastFunc != nil {
+		forecast, err = m.ForecastFunc(latitude, longitude, options...)
+	} else {
+		forecast = DefaultForecast(latitude, longitude, m.clock().Now(), call.Options)
+	}
+
 	if err == nil {
-		m.Cache.Set(cacheKey, forecast, 15*mocktime.Minute)
+		m.store(cacheKey, forecast, 15*mocktime.Minute)
 	}
 	return forecast, err
 }
 
 func (m *MockClient) TimeMachine(latitude, longitude float64, time mocktime.Time, options ...ForecastOption) (*models.ForecastResponse, error) {
-	m.initCache()
-	cacheKey := fmt.Sprintf("timemachine:%f:%f:%d:%v", latitude, longitude, time.Unix(), options)
-	if cachedForecast, found := m.Cache.Get(cacheKey); found {
-		return cachedForecast.(*models.ForecastResponse), nil
+	call := Call{
+		Method:    MethodTimeMachine,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Time:      time,
+		Options:   DecodeOptions(options...),
+	}
+
+	if response, scripted := m.next(MethodTimeMachine, latitude, longitude); scripted {
+		m.record(call)
+		return response.Forecast, response.Err
+	}
+
+	cacheKey := timeMachineCacheKey(latitude, longitude, time, options)
+	if forecast, found := m.cached(cacheKey); found {
+		call.Cached = true
+		m.record(call)
+		return forecast, nil
+	}
+	m.record(call)
+
+	var forecast *models.ForecastResponse
+	var err error
+	if m.TimeMachineFunc != nil {
+		forecast, err = m.TimeMachineFunc(latitude, longitude, time, options...)
+	} else {
+		forecast = DefaultForecast(latitude, longitude, time, call.Options)
+		forecast.Currently.Time = time.Unix()
 	}
-	forecast, err := m.TimeMachineFunc(latitude, longitude, time, options...)
+
 	if err == nil {
-		m.Cache.Set(cacheKey, forecast, 1*mocktime.Hour)
+		m.store(cacheKey, forecast, 1*mocktime.Hour)
 	}
 	return forecast, err
 }
@@ -52,3 +178,194 @@ func (m *MockClient) UpdateRateLimiter(headers http.Header) {
 		m.UpdateRateLimiterFunc(headers)
 	}
 }
+
+// Calls returns the calls made to the mock so far, including those served from the cache
+func (m *MockClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := make([]Call, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// CallsTo returns the calls made to the given method
+func (m *MockClient) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls and scripted responses and empties the cache
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.forecasts = nil
+	m.timeMachine = nil
+	m.Cache = nil
+}
+
+// AssertNumberOfCalls asserts that the method was called exactly n times
+func (m *MockClient) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+	if calls := len(m.CallsTo(method)); calls != n {
+		t.Errorf("expected %s to be called %d times, but it was called %d times", method, n, calls)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled asserts that the method was never called
+func (m *MockClient) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+	return m.AssertNumberOfCalls(t, method, 0)
+}
+
+// AssertCalledAt asserts that the method was called at least once for the location
+func (m *MockClient) AssertCalledAt(t TestingT, method string, latitude, longitude float64) bool {
+	t.Helper()
+	return m.assertCalled(t, method, fmt.Sprintf("at %f,%f", latitude, longitude), func(call Call) bool {
+		return call.Latitude == latitude && call.Longitude == longitude
+	})
+}
+
+// AssertCalledWithUnits asserts that the method was called at least once with the units option
+func (m *MockClient) AssertCalledWithUnits(t TestingT, method, units string) bool {
+	t.Helper()
+	return m.assertCalled(t, method, fmt.Sprintf("with units %q", units), func(call Call) bool {
+		return call.Options.Units == units
+	})
+}
+
+// AssertCalledWithOption asserts that the method was called at least once with the query parameter set to value
+func (m *MockClient) AssertCalledWithOption(t TestingT, method, name, value string) bool {
+	t.Helper()
+	return m.assertCalled(t, method, fmt.Sprintf("with %s=%q", name, value), func(call Call) bool {
+		return call.Options.Query.Get(name) == value
+	})
+}
+
+func (m *MockClient) assertCalled(t TestingT, method, description string, match func(Call) bool) bool {
+	t.Helper()
+	for _, call := range m.CallsTo(method) {
+		if match(call) {
+			return true
+		}
+	}
+	t.Errorf("expected %s to be called %s, but it was not: %+v", method, description, m.CallsTo(method))
+	return false
+}
+
+func (m *MockClient) record(call Call) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+}
+
+func (m *MockClient) cached(key string) (*models.ForecastResponse, bool) {
+	if m.DisableCache {
+		return nil, false
+	}
+	if cachedForecast, found := m.cache().Get(key); found {
+		return cachedForecast.(*models.ForecastResponse), true
+	}
+	return nil, false
+}
+
+func (m *MockClient) store(key string, forecast *models.ForecastResponse, expiration mocktime.Duration) {
+	if m.DisableCache {
+		return
+	}
+	m.cache().Set(key, forecast, expiration)
+}
+
+// next pops the next scripted response of the method for the location, keeping the last one in place
+func (m *MockClient) next(method string, latitude, longitude float64) (MockResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	scripts := m.forecasts
+	if method == MethodTimeMachine {
+		scripts = m.timeMachine
+	}
+	key := locationKey(latitude, longitude)
+	responses := scripts[key]
+	if len(responses) == 0 {
+		return MockResponse{}, false
+	}
+	response := responses[0]
+	if len(responses) > 1 {
+		scripts[key] = responses[1:]
+	}
+	return response, true
+}
+
+func locationKey(latitude, longitude float64) string {
+	return fmt.Sprintf("%f,%f", latitude, longitude)
+}
+
+// DefaultForecast returns the canned forecast served by a MockClient without a scripted response or func
+func DefaultForecast(latitude, longitude float64, now mocktime.Time, options RequestOptions) *models.ForecastResponse {
+	units := options.Units
+	if units == "" {
+		units = "us"
+	}
+
+	temperature := 20.5
+	if units == "us" {
+		temperature = 68.9
+	}
+
+	now = now.Truncate(mocktime.Hour)
+	forecast := &models.ForecastResponse{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Timezone:  "UTC",
+		Currently: &models.DataPoint{
+			Time:        now.Unix(),
+			Summary:     "Clear",
+			Icon:        "clear-day",
+			Temperature: temperature,
+			Humidity:    0.5,
+			Pressure:    1013.25,
+			CloudCover:  0.1,
+		},
+		Hourly: &models.DataBlock{Summary: "Clear throughout the day.", Icon: "clear-day"},
+		Daily:  &models.DataBlock{Summary: "Clear throughout the week.", Icon: "clear-day"},
+		Alerts: []models.Alert{},
+		Flags:  &models.Flags{Units: units, Version: "mock"},
+	}
+	// Like a version 1 response, the canned points leave out the fields only version 2 sends
+	forecast.Currently.SetAbsent("smoke", "fireIndex", "liquidAccumulation", "snowAccumulation", "iceAccumulation",
+		"dawnTime", "duskTime")
+	for i := 0; i < 48; i++ {
+		point := *forecast.Currently
+		point.Time = now.Add(mocktime.Duration(i) * mocktime.Hour).Unix()
+		forecast.Hourly.Data = append(forecast.Hourly.Data, point)
+	}
+	for i := 0; i < 8; i++ {
+		point := *forecast.Currently
+		point.Time = now.Truncate(24*mocktime.Hour).AddDate(0, 0, i).Unix()
+		point.TemperatureHigh = temperature + 3
+		point.TemperatureLow = temperature - 5
+		forecast.Daily.Data = append(forecast.Daily.Data, point)
+	}
+
+	for _, block := range options.Exclude {
+		switch block {
+		case "hourly":
+			forecast.Hourly = nil
+		case "daily":
+			forecast.Daily = nil
+		case "alerts":
+			forecast.Alerts = nil
+		case "flags":
+			forecast.Flags = nil
+		}
+	}
+	forecast.Localize()
+	return forecast
+}
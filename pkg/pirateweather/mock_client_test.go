@@ -0,0 +1,227 @@
+package pirateweather_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweather"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT captures assertion failures reported by the mock
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMockClientDefaultForecast(t *testing.T) {
+	mockClient := &pirateweather.MockClient{}
+
+	forecast, err := mockClient.Forecast(45.42, -75.69, pirateweather.WithUnits("si"), pirateweather.WithExclude([]string{"daily"}))
+	require.NoError(t, err)
+	require.Equal(t, 45.42, forecast.Latitude)
+	require.Equal(t, -75.69, forecast.Longitude)
+	require.Equal(t, 20.5, forecast.Currently.Temperature)
+	require.Len(t, forecast.Hourly.Data, 48)
+	require.Nil(t, forecast.Daily)
+	require.Equal(t, "si", forecast.Flags.Units)
+
+	timestamp := time.Unix(1620000000, 0)
+	history, err := mockClient.TimeMachine(45.42, -75.69, timestamp)
+	require.NoError(t, err)
+	require.Equal(t, int64(1620000000), history.Currently.Time)
+}
+
+func TestMockClientScriptedSequence(t *testing.T) {
+	mockClient := &pirateweather.MockClient{DisableCache: true}
+
+	first := &models.ForecastResponse{Latitude: 45.42, Currently: &models.DataPoint{Temperature: 10}}
+	last := &models.ForecastResponse{Latitude: 45.42, Currently: &models.DataPoint{Temperature: 12}}
+	failure := errors.New("temporary failure")
+
+	mockClient.OnForecast(45.42, -75.69,
+		pirateweather.MockResponse{Forecast: first},
+		pirateweather.MockResponse{Err: failure},
+		pirateweather.MockResponse{Forecast: last},
+	)
+
+	forecast, err := mockClient.Forecast(45.42, -75.69)
+	require.NoError(t, err)
+	require.Equal(t, first, forecast)
+
+	_, err = mockClient.Forecast(45.42, -75.69)
+	require.ErrorIs(t, err, failure)
+
+	for i := 0; i < 2; i++ {
+		forecast, err = mockClient.Forecast(45.42, -75.69)
+		require.NoError(t, err)
+		require.Equal(t, last, forecast)
+	}
+
+	// Other locations fall back to the canned forecast
+	forecast, err = mockClient.Forecast(51.5, -0.12)
+	require.NoError(t, err)
+	require.Equal(t, 51.5, forecast.Latitude)
+}
+
+func TestMockClientScriptedSuccessesBypassCache(t *testing.T) {
+	mockClient := &pirateweather.MockClient{}
+
+	first := &models.ForecastResponse{Latitude: 45.42, Currently: &models.DataPoint{Temperature: 10}}
+	second := &models.ForecastResponse{Latitude: 45.42, Currently: &models.DataPoint{Temperature: 12}}
+	mockClient.OnForecast(45.42, -75.69, pirateweather.MockResponse{Forecast: first}, pirateweather.MockResponse{Forecast: second})
+
+	forecast, err := mockClient.Forecast(45.42, -75.69)
+	require.NoError(t, err)
+	require.Equal(t, first, forecast)
+	forecast, err = mockClient.Forecast(45.42, -75.69)
+	require.NoError(t, err)
+	require.Equal(t, second, forecast)
+	for _, call := range mockClient.Calls() {
+		require.False(t, call.Cached)
+	}
+}
+
+func TestMockClientScriptedTimeMachine(t *testing.T) {
+	mockClient := &pirateweather.MockClient{}
+	history := &models.ForecastResponse{Latitude: 45.42, Currently: &models.DataPoint{Temperature: 3}}
+	mockClient.OnTimeMachine(45.42, -75.69, pirateweather.MockResponse{Forecast: history})
+
+	forecast, err := mockClient.TimeMachine(45.42, -75.69, time.Unix(1620000000, 0))
+	require.NoError(t, err)
+	require.Equal(t, history, forecast)
+}
+
+func TestMockClientRecordsCalls(t *testing.T) {
+	mockClient := &pirateweather.MockClient{}
+
+	_, err := mockClient.Forecast(45.42, -75.69,
+		pirateweather.WithUnits("si"),
+		pirateweather.WithExclude([]string{"minutely", "alerts"}),
+		pirateweather.WithExtend("hourly"),
+		pirateweather.WithVersion(2),
+	)
+	require.NoError(t, err)
+	_, err = mockClient.Forecast(45.42, -75.69,
+		pirateweather.WithUnits("si"),
+		pirateweather.WithExclude([]string{"minutely", "alerts"}),
+		pirateweather.WithExtend("hourly"),
+		pirateweather.WithVersion(2),
+	)
+	require.NoError(t, err)
+
+	calls := mockClient.Calls()
+	require.Len(t, calls, 2)
+	require.Equal(t, pirateweather.MethodForecast, calls[0].Method)
+	require.Equal(t, "si", calls[0].Options.Units)
+	require.Equal(t, []string{"minutely", "alerts"}, calls[0].Options.Exclude)
+	require.Equal(t, "hourly", calls[0].Options.Extend)
+	require.Equal(t, 2, calls[0].Options.Version)
+	require.False(t, calls[0].Cached)
+	require.True(t, calls[1].Cached)
+
+	require.True(t, mockClient.AssertNumberOfCalls(t, pirateweather.MethodForecast, 2))
+	require.True(t, mockClient.AssertNotCalled(t, pirateweather.MethodTimeMachine))
+	require.True(t, mockClient.AssertCalledWithUnits(t, pirateweather.MethodForecast, "si"))
+	require.True(t, mockClient.AssertCalledWithOption(t, pirateweather.MethodForecast, "extend", "hourly"))
+	require.True(t, mockClient.AssertCalledAt(t, pirateweather.MethodForecast, 45.42, -75.69))
+
+	recorder := &recordingT{}
+	require.False(t, mockClient.AssertCalledWithUnits(recorder, pirateweather.MethodForecast, "us"))
+	require.False(t, mockClient.AssertNumberOfCalls(recorder, pirateweather.MethodForecast, 1))
+	require.Len(t, recorder.errors, 2)
+
+	mockClient.Reset()
+	require.Empty(t, mockClient.Calls())
+}
+
+func TestMockClientResetDuringRequests(t *testing.T) {
+	// Run with -race: Reset clears the cache and scripts while requests use them
+	mockClient := &pirateweather.MockClient{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := mockClient.Forecast(45.42, float64(-75-i))
+				require.NoError(t, err)
+				_, err = mockClient.TimeMachine(45.42, -75.69, time.Unix(int64(j)*3600, 0))
+				require.NoError(t, err)
+			}
+		}(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			mockClient.OnForecast(45.42, -75, pirateweather.MockResponse{Forecast: &models.ForecastResponse{}})
+			mockClient.Reset()
+		}
+	}
+}
+
+func TestMockClientCacheSeparatesOptions(t *testing.T) {
+	mockClient := &pirateweather.MockClient{}
+
+	si, err := mockClient.Forecast(45.42, -75.69, pirateweather.WithUnits("si"))
+	require.NoError(t, err)
+	us, err := mockClient.Forecast(45.42, -75.69, pirateweather.WithUnits("us"))
+	require.NoError(t, err)
+
+	require.Equal(t, "si", si.Flags.Units)
+	require.Equal(t, "us", us.Flags.Units)
+}
+
+func TestMockClientDisableCache(t *testing.T) {
+	callCount := 0
+	mockClient := &pirateweather.MockClient{
+		DisableCache: true,
+		ForecastFunc: func(latitude, longitude float64, options ...pirateweather.ForecastOption) (*models.ForecastResponse, error) {
+			callCount++
+			return &models.ForecastResponse{Latitude: latitude, Longitude: longitude}, nil
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := mockClient.Forecast(45.42, -75.69)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, callCount)
+	require.Nil(t, mockClient.Cache)
+}
+
+func TestDecodeOptions(t *testing.T) {
+	options := pirateweather.DecodeOptions(
+		pirateweather.WithUnits("ca"),
+		pirateweather.WithVersion(2),
+	)
+
+	require.Equal(t, "ca", options.Units)
+	require.Equal(t, 2, options.Version)
+	require.Empty(t, options.Exclude)
+	require.Equal(t, "ca", options.Query.Get("units"))
+}
+
+func TestDecodeLanguage(t *testing.T) {
+	options := pirateweather.DecodeOptions(pirateweather.WithLanguage("fr"), pirateweather.WithUnits("si"))
+
+	require.Equal(t, "fr", options.Language)
+	require.Equal(t, "fr", options.Query.Get("lang"))
+	require.Empty(t, pirateweather.DecodeOptions().Language)
+}
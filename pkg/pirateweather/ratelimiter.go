@@ -12,15 +12,22 @@ type RateLimiter struct {
 	tokens       float64
 	lastRefilled time.Time
 	refillRate   float64
+	clock        Clock
 }
 
 // NewRateLimiter creates a new RateLimiter with the given limit
 func NewRateLimiter(limit int) *RateLimiter {
+	return NewRateLimiterWithClock(limit, SystemClock)
+}
+
+// NewRateLimiterWithClock creates a new RateLimiter with the given limit that refills according to the given clock
+func NewRateLimiterWithClock(limit int, clock Clock) *RateLimiter {
 	return &RateLimiter{
 		limit:        limit,
 		tokens:       float64(limit),
-		lastRefilled: time.Now(),
+		lastRefilled: clock.Now(),
 		refillRate:   float64(limit) / (30 * 24 * 60 * 60), // Tokens per second for a month
+		clock:        clock,
 	}
 }
 
@@ -29,7 +36,7 @@ func (rl *RateLimiter) Allow() bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 	elapsed := now.Sub(rl.lastRefilled).Seconds()
 	rl.tokens = min(float64(rl.limit), rl.tokens+elapsed*rl.refillRate)
 	rl.lastRefilled = now
@@ -48,8 +55,8 @@ func (rl *RateLimiter) UpdateFromHeaders(limit, remaining int, reset time.Time)
 
 	rl.limit = limit
 	rl.tokens = float64(remaining)
-	rl.lastRefilled = time.Now()
-	rl.refillRate = float64(limit) / time.Until(reset).Seconds()
+	rl.lastRefilled = rl.clock.Now()
+	rl.refillRate = float64(limit) / reset.Sub(rl.lastRefilled).Seconds()
 }
 
 func min(a, b float64) float64 {
@@ -19,7 +19,8 @@ func TestRateLimiter(t *testing.T) {
 }
 
 func TestRateLimiterReset(t *testing.T) {
-	rl := pirateweather.NewRateLimiter(5)
+	clock := pirateweather.NewFakeClock(time.Unix(1620000000, 0))
+	rl := pirateweather.NewRateLimiterWithClock(5, clock)
 
 	for i := 0; i < 5; i++ {
 		require.True(t, rl.Allow())
@@ -27,10 +28,31 @@ func TestRateLimiterReset(t *testing.T) {
 
 	require.False(t, rl.Allow())
 
-	rl.UpdateFromHeaders(5, 5, time.Now().Add(time.Second))
-	time.Sleep(time.Second * 2)
+	rl.UpdateFromHeaders(5, 0, clock.Now().Add(time.Second))
+	require.False(t, rl.Allow())
+
+	clock.Advance(time.Second * 2)
+
+	require.True(t, rl.Allow())
+}
+
+func TestRateLimiterRefillsWithClock(t *testing.T) {
+	t.Parallel()
 
+	clock := pirateweather.NewFakeClock(time.Unix(1620000000, 0))
+	rl := pirateweather.NewRateLimiterWithClock(30, clock)
+
+	for i := 0; i < 30; i++ {
+		require.True(t, rl.Allow())
+	}
+	require.False(t, rl.Allow())
+
+	// 30 requests per 30 days refill one token per day
+	clock.Advance(12 * time.Hour)
+	require.False(t, rl.Allow())
+	clock.Advance(12 * time.Hour)
 	require.True(t, rl.Allow())
+	require.False(t, rl.Allow())
 }
 
 func TestRateLimiterUpdateFromHeaders(t *testing.T) {
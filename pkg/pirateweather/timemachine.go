@@ -11,7 +11,7 @@ import (
 
 // TimeMachine retrieves historical weather data for a given location and time
 func (c *Client) TimeMachine(latitude, longitude float64, timestamp time.Time, options ...ForecastOption) (*models.ForecastResponse, error) {
-	cacheKey := fmt.Sprintf("timemachine:%f:%f:%d:%v", latitude, longitude, timestamp.Unix(), options)
+	cacheKey := timeMachineCacheKey(latitude, longitude, timestamp, options)
 	if cachedForecast, found := c.Cache.Get(cacheKey); found {
 		return cachedForecast.(*models.ForecastResponse), nil
 	}
@@ -20,7 +20,7 @@ func (c *Client) TimeMachine(latitude, longitude float64, timestamp time.Time, o
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrCreateRequest, err)
 	}
 
 	for _, option := range options {
@@ -33,13 +33,14 @@ func (c *Client) TimeMachine(latitude, longitude float64, timestamp time.Time, o
 	for i := 0; i < maxRetries; i++ {
 		if !c.RateLimiter.Allow() {
 			return nil, &RateLimitError{
-				Message: "rate limit exceeded",
+				Message: ErrRateLimited.Error(),
+				Err:     ErrRateLimited,
 			}
 		}
 
 		resp, err = c.HTTPClient.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("error making request: %w", err)
+			return nil, fmt.Errorf("%w: %w", ErrRequest, err)
 		}
 		defer resp.Body.Close()
 
@@ -48,23 +49,29 @@ func (c *Client) TimeMachine(latitude, longitude float64, timestamp time.Time, o
 		}
 
 		if resp.StatusCode != http.StatusInternalServerError {
+			err := fmt.Errorf("%w: %d", ErrStatus, resp.StatusCode)
 			return nil, &APIError{
-				Message: fmt.Sprintf("API request failed with status code: %d", resp.StatusCode),
+				Message: err.Error(),
+				Err:     err,
 			}
 		}
 
-		time.Sleep(retryDelay)
+		c.Clock().Sleep(retryDelay)
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		err := &RetryError{Retries: maxRetries}
 		return nil, &APIError{
-			Message: fmt.Sprintf("API request failed after %d retries", maxRetries),
+			Message: err.Error(),
+			Err:     err,
 		}
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&forecast); err != nil {
+		err = fmt.Errorf("%w: %w", ErrDecode, err)
 		return nil, &JSONError{
-			Message: fmt.Sprintf("error decoding response: %v", err),
+			Message: err.Error(),
+			Err:     err,
 		}
 	}
 
@@ -0,0 +1,69 @@
+package pirateweathertest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+)
+
+const (
+	hourlyPoints         = 48
+	extendedHourlyPoints = 168
+	dailyPoints          = 8
+	minutelyPoints       = 61
+)
+
+// generate builds a synthetic forecast honouring the units, exclude, extend and version parameters
+func generate(req Request) *models.ForecastResponse {
+	units := req.Query["units"]
+	if units == "" {
+		units = "us"
+	}
+	version, _ := strconv.Atoi(req.Query["version"])
+	if version == 0 {
+		version = 1
+	}
+
+	options := []synthetic.Option{
+		synthetic.WithUnits(units),
+		synthetic.WithVersion(version),
+	}
+
+	var forecast *models.ForecastResponse
+	if req.Time != nil {
+		forecast = synthetic.TimeMachine(req.Latitude, req.Longitude, *req.Time, options...)
+	} else {
+		hours := hourlyPoints
+		if req.Query["extend"] == "hourly" {
+			hours = extendedHourlyPoints
+		}
+		options = append(options,
+			synthetic.WithMinutes(minutelyPoints),
+			synthetic.WithHours(hours),
+			synthetic.WithDays(dailyPoints),
+		)
+		forecast = synthetic.Generate(req.Latitude, req.Longitude, time.Now(), options...)
+	}
+
+	for _, block := range strings.Split(req.Query["exclude"], ",") {
+		switch strings.TrimSpace(block) {
+		case "currently":
+			forecast.Currently = nil
+		case "minutely":
+			forecast.Minutely = nil
+		case "hourly":
+			forecast.Hourly = nil
+		case "daily":
+			forecast.Daily = nil
+		case "alerts":
+			forecast.Alerts = nil
+		case "flags":
+			forecast.Flags = nil
+		}
+	}
+
+	return forecast
+}
@@ -0,0 +1,273 @@
+// Package pirateweathertest provides a fake Pirate Weather API server for integration testing
+package pirateweathertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweather"
+)
+
+const (
+	// DefaultAPIKey is the API key accepted by a new Server
+	DefaultAPIKey = "test-api-key"
+
+	// DefaultRateLimit is the monthly quota advertised in the rate limit headers
+	DefaultRateLimit = 10000
+)
+
+// Reply is a scripted reply served instead of a generated forecast.
+// A zero Status serves the generated forecast after Delay.
+type Reply struct {
+	Status int
+	Header http.Header
+	Body   string
+	Delay  time.Duration
+}
+
+// Request records a request received by the Server
+type Request struct {
+	Method    string
+	Path      string
+	APIKey    string
+	Latitude  float64
+	Longitude float64
+	Time      *time.Time
+	Query     map[string]string
+}
+
+// Server is an httptest.Server emulating the forecast and time machine endpoints
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the only key the server accepts; requests with any other key get a 401
+	APIKey string
+
+	// RateLimit is the quota advertised in the Ratelimit-Limit header
+	RateLimit int
+
+	mu        sync.Mutex
+	replies   []Reply
+	requests  []Request
+	remaining int
+}
+
+// NewServer starts a new fake Pirate Weather server. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    DefaultAPIKey,
+		RateLimit: DefaultRateLimit,
+		remaining: DefaultRateLimit,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// BaseURL returns the forecast base URL to use as Client.BaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + "/forecast"
+}
+
+// Client returns a Pirate Weather client configured to talk to the server
+func (s *Server) Client(options ...pirateweather.ClientOption) *pirateweather.Client {
+	client := pirateweather.NewClient(s.APIKey, options...)
+	client.BaseURL = s.BaseURL()
+	client.HTTPClient = s.Server.Client()
+	return client
+}
+
+// Enqueue scripts replies for the next requests, served in order
+func (s *Server) Enqueue(replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies = append(s.replies, replies...)
+}
+
+// Unauthorized makes the next request fail with a 401
+func (s *Server) Unauthorized() {
+	s.Enqueue(Reply{
+		Status: http.StatusUnauthorized,
+		Body:   `{"message":"Forbidden"}`,
+	})
+}
+
+// RateLimited makes the next request fail with a 429 carrying the given rate limit headers
+func (s *Server) RateLimited(limit, remaining int, reset time.Time) {
+	header := make(http.Header)
+	header.Set("Ratelimit-Limit", strconv.Itoa(limit))
+	header.Set("Ratelimit-Remaining", strconv.Itoa(remaining))
+	header.Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	s.Enqueue(Reply{
+		Status: http.StatusTooManyRequests,
+		Header: header,
+		Body:   `{"message":"Too Many Requests"}`,
+	})
+}
+
+// ServerErrors makes the next n requests fail with a 500
+func (s *Server) ServerErrors(n int) {
+	for i := 0; i < n; i++ {
+		s.Enqueue(Reply{
+			Status: http.StatusInternalServerError,
+			Body:   `{"message":"Internal Server Error"}`,
+		})
+	}
+}
+
+// Slow delays the next response by d before serving the generated forecast
+func (s *Server) Slow(d time.Duration) {
+	s.Enqueue(Reply{Delay: d})
+}
+
+// MalformedJSON makes the next request succeed with a body that is not valid JSON
+func (s *Server) MalformedJSON() {
+	s.Enqueue(Reply{
+		Status: http.StatusOK,
+		Body:   `{"latitude": 45.42, "longitude": invalid`,
+	})
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+func (s *Server) nextReply() (Reply, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.replies) == 0 {
+		return Reply{}, false
+	}
+	reply := s.replies[0]
+	s.replies = s.replies[1:]
+	return reply, true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	req, err := parseRequest(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	reply, scripted := s.nextReply()
+	if reply.Delay > 0 {
+		select {
+		case <-time.After(reply.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if scripted && reply.Status != 0 {
+		for key, values := range reply.Header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(reply.Status)
+		fmt.Fprint(w, reply.Body)
+		return
+	}
+
+	if req.APIKey != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "Forbidden")
+		return
+	}
+
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+		writeError(w, http.StatusBadRequest, "Invalid latitude or longitude")
+		return
+	}
+
+	forecast := generate(req)
+	s.writeRateLimitHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(forecast); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// writeError writes a JSON error body as the API does, e.g. {"message":"Forbidden"}
+func writeError(w http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(struct {
+		Message string `json:"message"`
+	}{message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func (s *Server) writeRateLimitHeaders(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.remaining > 0 {
+		s.remaining--
+	}
+	now := time.Now()
+	reset := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	w.Header().Set("Ratelimit-Limit", strconv.Itoa(s.RateLimit))
+	w.Header().Set("Ratelimit-Remaining", strconv.Itoa(s.remaining))
+	w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+}
+
+// parseRequest extracts the API key, location and optional time from /forecast/{key}/{lat},{lon}[,{time}]
+func parseRequest(r *http.Request) (Request, error) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "forecast" {
+		return Request{}, fmt.Errorf("invalid route: %s", r.URL.Path)
+	}
+
+	coords := strings.Split(parts[2], ",")
+	if len(coords) != 2 && len(coords) != 3 {
+		return Request{}, fmt.Errorf("missing latitude or longitude")
+	}
+
+	latitude, err := strconv.ParseFloat(coords[0], 64)
+	if err != nil {
+		return Request{}, fmt.Errorf("invalid latitude: %s", coords[0])
+	}
+	longitude, err := strconv.ParseFloat(coords[1], 64)
+	if err != nil {
+		return Request{}, fmt.Errorf("invalid longitude: %s", coords[1])
+	}
+
+	req := Request{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		APIKey:    parts[1],
+		Latitude:  latitude,
+		Longitude: longitude,
+		Query:     make(map[string]string),
+	}
+
+	if len(coords) == 3 {
+		timestamp, err := strconv.ParseInt(coords[2], 10, 64)
+		if err != nil {
+			return Request{}, fmt.Errorf("invalid time: %s", coords[2])
+		}
+		t := time.Unix(timestamp, 0).UTC()
+		req.Time = &t
+	}
+
+	for key := range r.URL.Query() {
+		req.Query[key] = r.URL.Query().Get(key)
+	}
+
+	return req, nil
+}
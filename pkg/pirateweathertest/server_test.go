@@ -0,0 +1,134 @@
+package pirateweathertest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/pirateweathertest"
+	"github.com/stretchr/testify/require"
+)
+
+func getForecast(t *testing.T, url string) (*http.Response, *models.ForecastResponse) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var forecast models.ForecastResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&forecast))
+	}
+	return resp, &forecast
+}
+
+func TestServerForecast(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	resp, forecast := getForecast(t, server.BaseURL()+"/test-api-key/45.42,-75.69?units=si")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "10000", resp.Header.Get("Ratelimit-Limit"))
+	require.Equal(t, "9999", resp.Header.Get("Ratelimit-Remaining"))
+
+	require.Equal(t, 45.42, forecast.Latitude)
+	require.Equal(t, -75.69, forecast.Longitude)
+	require.NotNil(t, forecast.Currently)
+	require.Len(t, forecast.Minutely.Data, 61)
+	require.Len(t, forecast.Hourly.Data, 48)
+	require.Len(t, forecast.Daily.Data, 8)
+	require.Equal(t, "si", forecast.Flags.Units)
+	require.Less(t, forecast.Currently.Temperature, 50.0)
+}
+
+func TestServerHonoursOptions(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	_, si := getForecast(t, server.BaseURL()+"/test-api-key/45.42,-75.69?units=si")
+	_, us := getForecast(t, server.BaseURL()+"/test-api-key/45.42,-75.69?units=us&exclude=minutely,daily&extend=hourly")
+
+	require.Equal(t, "us", us.Flags.Units)
+	require.InDelta(t, si.Hourly.Data[0].Temperature*9/5+32, us.Hourly.Data[0].Temperature, 0.05)
+	require.Nil(t, us.Minutely)
+	require.Nil(t, us.Daily)
+	require.Len(t, us.Hourly.Data, 168)
+
+	requests := server.Requests()
+	require.Len(t, requests, 2)
+	require.Equal(t, "minutely,daily", requests[1].Query["exclude"])
+}
+
+func TestServerTimeMachine(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	resp, forecast := getForecast(t, server.BaseURL()+"/test-api-key/45.42,-75.69,1620000000")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Nil(t, forecast.Minutely)
+	require.Len(t, forecast.Hourly.Data, 24)
+	require.Len(t, forecast.Daily.Data, 1)
+	require.Equal(t, int64(1620000000), forecast.Currently.Time)
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, int64(1620000000), requests[0].Time.Unix())
+}
+
+func TestServerScriptedFailures(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	reset := time.Unix(1700000000, 0)
+	server.Unauthorized()
+	server.RateLimited(100, 0, reset)
+	server.ServerErrors(2)
+	server.MalformedJSON()
+
+	url := server.BaseURL() + "/test-api-key/45.42,-75.69"
+
+	resp, _ := getForecast(t, url)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = getForecast(t, url)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, "100", resp.Header.Get("Ratelimit-Limit"))
+	require.Equal(t, "0", resp.Header.Get("Ratelimit-Remaining"))
+	require.Equal(t, "1700000000", resp.Header.Get("Ratelimit-Reset"))
+
+	for i := 0; i < 2; i++ {
+		resp, _ = getForecast(t, url)
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	}
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var forecast models.ForecastResponse
+	require.Error(t, json.NewDecoder(resp.Body).Decode(&forecast))
+
+	resp, _ = getForecast(t, url)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServerRejectsInvalidRequests(t *testing.T) {
+	server := pirateweathertest.NewServer()
+	defer server.Close()
+
+	resp, _ := getForecast(t, server.BaseURL()+"/wrong-key/45.42,-75.69")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = getForecast(t, server.BaseURL()+"/test-api-key/95,-75.69")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err := http.Get(server.BaseURL() + "/test-api-key")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var body struct{ Message string }
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.NotEmpty(t, body.Message)
+}
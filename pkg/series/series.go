@@ -0,0 +1,200 @@
+// Package series interpolates and resamples the data points of a forecast block
+package series
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+var (
+	// ErrEmpty is returned when a series has no data points
+	ErrEmpty = errors.New("series has no data points")
+
+	// ErrOutOfRange is returned for times before the first or after the last data point
+	ErrOutOfRange = errors.New("time outside the series")
+
+	// ErrUnknownField is returned for names that are not numeric DataPoint fields
+	ErrUnknownField = errors.New("not a numeric field")
+
+	// ErrMissingValue is returned when a value next to the requested time is missing
+	ErrMissingValue = errors.New("value missing")
+
+	// ErrInvalidInterval is returned when resampling with a non-positive interval
+	ErrInvalidInterval = errors.New("interval must be positive")
+)
+
+// interpolatedFields are the float fields; text and time fields step
+var interpolatedFields = models.SelectFields(func(field models.Field) bool {
+	return field.Kind == models.FieldFloat
+})
+
+// Series is a time-ordered run of data points, such as an hourly or minutely block, that can be
+// queried at any instant between its first and last point.
+//
+// Numeric fields are interpolated linearly, bearings circularly, and text fields such as PrecipType
+// and Icon, as well as time fields such as SunriseTime, step: they keep the value of the preceding point.
+type Series struct {
+	points []models.DataPoint
+}
+
+// New returns a series over the block's data points
+func New(block *models.DataBlock) (*Series, error) {
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	return FromPoints(block.Data)
+}
+
+// FromPoints returns a series over the data points, which need not be sorted
+func FromPoints(points []models.DataPoint) (*Series, error) {
+	if len(points) == 0 {
+		return nil, ErrEmpty
+	}
+	sorted := make([]models.DataPoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+	return &Series{points: sorted}, nil
+}
+
+// Start returns the time of the first data point
+func (s *Series) Start() time.Time {
+	return s.points[0].LocalTime()
+}
+
+// End returns the time of the last data point
+func (s *Series) End() time.Time {
+	return s.points[len(s.points)-1].LocalTime()
+}
+
+// Points returns the data points of the series in time order
+func (s *Series) Points() []models.DataPoint {
+	return s.points
+}
+
+// Value returns the numeric field with the given JSON name at t
+func (s *Series) Value(name string, t time.Time) (float64, error) {
+	field, ok := models.LookupField(name)
+	if !ok || !field.IsNumeric() {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownField, name)
+	}
+	before, after, fraction, err := s.bracket(t)
+	if err != nil {
+		return 0, err
+	}
+	if name == "time" {
+		return float64(t.Unix()), nil
+	}
+
+	a, okA := before.Value(name)
+	b, okB := after.Value(name)
+	if field.Kind == models.FieldTime || fraction == 0 {
+		if !okA {
+			return 0, fmt.Errorf("%w: %s at %s", ErrMissingValue, name, t.Format(time.RFC3339))
+		}
+		return a, nil
+	}
+	if !okA || !okB {
+		return 0, fmt.Errorf("%w: %s at %s", ErrMissingValue, name, t.Format(time.RFC3339))
+	}
+	return interpolate(field, a, b, fraction), nil
+}
+
+// At returns a data point for t with every field interpolated. Fields missing on either side of t are absent.
+func (s *Series) At(t time.Time) (models.DataPoint, error) {
+	before, after, fraction, err := s.bracket(t)
+	if err != nil {
+		return models.DataPoint{}, err
+	}
+
+	point := *before
+	if fraction > 0 {
+		for _, field := range interpolatedFields {
+			a, okA := field.Value(before)
+			b, okB := field.Value(after)
+			if !okA || !okB {
+				point.SetAbsent(field.Name)
+				continue
+			}
+			field.SetValue(&point, interpolate(field, a, b, fraction))
+		}
+	}
+	point.SetValue("time", float64(t.Unix()))
+	return point, nil
+}
+
+// Resample returns data points every interval from the start to the end of the series
+func (s *Series) Resample(interval time.Duration) ([]models.DataPoint, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	var points []models.DataPoint
+	end := s.End()
+	for t := s.Start(); !t.After(end); t = t.Add(interval) {
+		point, err := s.At(t)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// ResampleBlock resamples the block's data points every interval, keeping its summary and icon
+func ResampleBlock(block *models.DataBlock, interval time.Duration) (*models.DataBlock, error) {
+	s, err := New(block)
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.Resample(interval)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DataBlock{Summary: block.Summary, Icon: block.Icon, Data: points}, nil
+}
+
+// bracket finds the points before and after t and how far t lies between them (0 at before, 1 at after)
+func (s *Series) bracket(t time.Time) (before, after *models.DataPoint, fraction float64, err error) {
+	unix := float64(t.UnixNano()) / 1e9
+	first, last := s.points[0].Time, s.points[len(s.points)-1].Time
+	if unix < float64(first) || unix > float64(last) {
+		return nil, nil, 0, fmt.Errorf("%w: %s not in [%s, %s]", ErrOutOfRange,
+			t.Format(time.RFC3339), s.Start().Format(time.RFC3339), s.End().Format(time.RFC3339))
+	}
+
+	// Index of the first point after t
+	i := sort.Search(len(s.points), func(i int) bool { return float64(s.points[i].Time) > unix })
+	if i == len(s.points) {
+		return &s.points[i-1], &s.points[i-1], 0, nil
+	}
+	before, after = &s.points[i-1], &s.points[i]
+	fraction = (unix - float64(before.Time)) / float64(after.Time-before.Time)
+	return before, after, fraction, nil
+}
+
+// interpolate interpolates linearly, or along the shortest arc for the circular fields
+func interpolate(field models.Field, a, b, fraction float64) float64 {
+	period := cycle(field)
+	if period == 0 {
+		return a + (b-a)*fraction
+	}
+	delta := math.Mod(b-a+1.5*period, period) - period/2
+	return math.Mod(a+delta*fraction+period, period)
+}
+
+// cycle returns the period of a circular field: 360 for bearings and 1 for the moon phase, which
+// wraps from 0.99 back to 0 at the new moon. It is 0 for other fields.
+func cycle(field models.Field) float64 {
+	switch {
+	case field.Quantity == models.QuantityBearing:
+		return 360
+	case field.Name == "moonPhase":
+		return 1
+	default:
+		return 0
+	}
+}
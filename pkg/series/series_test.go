@@ -0,0 +1,143 @@
+package series_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/series"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func hourly() *models.DataBlock {
+	return &models.DataBlock{
+		Summary: "Rain later.",
+		Icon:    "rain",
+		Data: []models.DataPoint{
+			{Time: start.Unix(), Temperature: 10, WindBearing: 350, PrecipType: "none", Icon: "cloudy"},
+			{Time: start.Add(time.Hour).Unix(), Temperature: 14, WindBearing: 30, PrecipType: "rain", Icon: "rain"},
+			{Time: start.Add(2 * time.Hour).Unix(), Temperature: 12, WindBearing: 90, PrecipType: "rain", Icon: "rain"},
+		},
+	}
+}
+
+func TestSeriesValue(t *testing.T) {
+	s, err := series.New(hourly())
+	require.NoError(t, err)
+
+	temperature, err := s.Value("temperature", start.Add(15*time.Minute))
+	require.NoError(t, err)
+	require.InDelta(t, 11, temperature, 1e-9)
+
+	temperature, err = s.Value("temperature", start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.InDelta(t, 12, temperature, 1e-9)
+
+	// Bearings interpolate across north rather than through south
+	bearing, err := s.Value("windBearing", start.Add(30*time.Minute))
+	require.NoError(t, err)
+	require.InDelta(t, 10, bearing, 1e-9)
+
+	bearing, err = s.Value("windBearing", start.Add(15*time.Minute))
+	require.NoError(t, err)
+	require.InDelta(t, 0, bearing, 1e-9)
+
+	_, err = s.Value("temperature", start.Add(-time.Minute))
+	require.ErrorIs(t, err, series.ErrOutOfRange)
+	_, err = s.Value("temperature", start.Add(3*time.Hour))
+	require.ErrorIs(t, err, series.ErrOutOfRange)
+	_, err = s.Value("summary", start)
+	require.ErrorIs(t, err, series.ErrUnknownField)
+}
+
+func TestSeriesValueWrapsMoonPhase(t *testing.T) {
+	day := 24 * time.Hour
+	s, err := series.New(&models.DataBlock{Data: []models.DataPoint{
+		{Time: start.Unix(), MoonPhase: 0.98},
+		{Time: start.Add(day).Unix(), MoonPhase: 0.02},
+		{Time: start.Add(2 * day).Unix(), MoonPhase: 0.06},
+	}})
+	require.NoError(t, err)
+
+	// The new moon lies between 0.98 and 0.02, not the full moon at 0.5
+	phase, err := s.Value("moonPhase", start.Add(day/4))
+	require.NoError(t, err)
+	require.InDelta(t, 0.99, phase, 1e-9)
+
+	phase, err = s.Value("moonPhase", start.Add(day*3/4))
+	require.NoError(t, err)
+	require.InDelta(t, 0.01, phase, 1e-9)
+
+	phase, err = s.Value("moonPhase", start.Add(day*3/2))
+	require.NoError(t, err)
+	require.InDelta(t, 0.04, phase, 1e-9)
+}
+
+func TestSeriesAtSteps(t *testing.T) {
+	s, err := series.New(hourly())
+	require.NoError(t, err)
+
+	point, err := s.At(start.Add(45 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, start.Add(45*time.Minute).Unix(), point.Time)
+	require.InDelta(t, 13, point.Temperature, 1e-9)
+	require.Equal(t, "none", point.PrecipType)
+	require.Equal(t, "cloudy", point.Icon)
+
+	point, err = s.At(start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "rain", point.PrecipType)
+}
+
+func TestSeriesMissingValues(t *testing.T) {
+	var block models.DataBlock
+	require.NoError(t, json.Unmarshal([]byte(`{"data": [
+		{"time": 1717243200, "temperature": 10, "humidity": 0.5},
+		{"time": 1717246800, "temperature": 12, "humidity": -999}
+	]}`), &block))
+
+	s, err := series.New(&block)
+	require.NoError(t, err)
+
+	_, err = s.Value("humidity", time.Unix(1717245000, 0))
+	require.ErrorIs(t, err, series.ErrMissingValue)
+	_, err = s.Value("windSpeed", time.Unix(1717245000, 0))
+	require.ErrorIs(t, err, series.ErrMissingValue)
+
+	point, err := s.At(time.Unix(1717245000, 0))
+	require.NoError(t, err)
+	require.InDelta(t, 11, point.Temperature, 1e-9)
+	require.True(t, point.IsMissing("humidity"))
+	require.True(t, point.IsMissing("windSpeed"))
+}
+
+func TestResample(t *testing.T) {
+	block, err := series.ResampleBlock(hourly(), 15*time.Minute)
+	require.NoError(t, err)
+	require.Len(t, block.Data, 9)
+	require.Equal(t, "Rain later.", block.Summary)
+	for i, point := range block.Data {
+		require.Equal(t, start.Add(time.Duration(i)*15*time.Minute).Unix(), point.Time)
+	}
+	require.InDelta(t, 13, block.Data[3].Temperature, 1e-9)
+
+	s, err := series.New(hourly())
+	require.NoError(t, err)
+	_, err = s.Resample(0)
+	require.ErrorIs(t, err, series.ErrInvalidInterval)
+
+	_, err = series.New(&models.DataBlock{})
+	require.ErrorIs(t, err, series.ErrEmpty)
+}
+
+func TestResampleKeepsLocation(t *testing.T) {
+	forecast := synthetic.Generate(48.85, 2.35, start, synthetic.WithTimezone("Europe/Paris"))
+	block, err := series.ResampleBlock(forecast.Hourly, 10*time.Minute)
+	require.NoError(t, err)
+	require.Len(t, block.Data, (len(forecast.Hourly.Data)-1)*6+1)
+	require.Equal(t, "Europe/Paris", block.Data[1].LocalTime().Location().String())
+}
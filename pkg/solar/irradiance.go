@@ -0,0 +1,88 @@
+// Package solar estimates solar irradiance from the sun's geometry and the forecast cloud cover,
+// projects it onto tilted panels and estimates the output of photovoltaic systems.
+package solar
+
+import (
+	"math"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+)
+
+const degrees = math.Pi / 180
+
+// Irradiance is the solar irradiance in W/m² at a time, with the sun's position in degrees
+type Irradiance struct {
+	Time      time.Time
+	Elevation float64
+	Azimuth   float64
+	GHI       float64 // global horizontal
+	DNI       float64 // direct normal
+	DHI       float64 // diffuse horizontal
+}
+
+// extraterrestrial returns the irradiance normal to the sun's rays outside the atmosphere at t
+func extraterrestrial(t time.Time) float64 {
+	return astronomy.SolarConstant * (1 + 0.033*math.Cos(2*math.Pi*float64(t.YearDay())/365))
+}
+
+// airMass returns the relative optical air mass at a solar elevation in degrees (Kasten and Young)
+func airMass(elevation float64) float64 {
+	zenith := 90 - elevation
+	return 1 / (math.Cos(zenith*degrees) + 0.50572*math.Pow(96.07995-zenith, -1.6364))
+}
+
+// ClearSky returns the cloudless sky irradiance at a location: the global horizontal irradiance
+// from the Haurwitz model and the direct normal irradiance from the Meinel model, with the
+// diffuse irradiance making up the difference. It is zero while the sun is below the horizon.
+func ClearSky(t time.Time, latitude, longitude float64) Irradiance {
+	position := astronomy.SunPosition(t, latitude, longitude)
+	i := Irradiance{Time: t, Elevation: position.Elevation, Azimuth: position.Azimuth}
+	if position.Elevation <= 0 {
+		return i
+	}
+	cosZenith := math.Sin(position.Elevation * degrees)
+	scale := extraterrestrial(t) / astronomy.SolarConstant
+	i.GHI = 1098 * cosZenith * math.Exp(-0.057/cosZenith) * scale
+	i.DNI = extraterrestrial(t) * math.Pow(0.7, math.Pow(airMass(position.Elevation), 0.678))
+	i.DNI = math.Min(i.DNI, i.GHI/cosZenith)
+	i.DHI = i.GHI - i.DNI*cosZenith
+	return i
+}
+
+// Attenuate returns the irradiance under a cloud cover from 0 to 1. The global irradiance falls
+// after Kasten and Czeplak, and the diffuse share grows with the square of the cloud cover until
+// an overcast sky lets no direct irradiance through.
+func (i Irradiance) Attenuate(cloudCover float64) Irradiance {
+	if i.GHI <= 0 {
+		return i
+	}
+	cloudCover = math.Max(0, math.Min(cloudCover, 1))
+	diffuseFraction := i.DHI / i.GHI
+	diffuseFraction += (1 - diffuseFraction) * cloudCover * cloudCover
+
+	cloudy := i
+	cloudy.GHI = i.GHI * (1 - 0.75*math.Pow(cloudCover, 3.4))
+	cloudy.DHI = cloudy.GHI * diffuseFraction
+	cloudy.DNI = (cloudy.GHI - cloudy.DHI) / math.Sin(i.Elevation*degrees)
+	return cloudy
+}
+
+// Incidence returns the cosine of the angle between the sun's rays and the normal of a plane tilted
+// from horizontal and facing an azimuth, both in degrees, or 0 when the sun is behind the plane
+func (i Irradiance) Incidence(tilt, azimuth float64) float64 {
+	zenith := (90 - i.Elevation) * degrees
+	cos := math.Cos(zenith)*math.Cos(tilt*degrees) +
+		math.Sin(zenith)*math.Sin(tilt*degrees)*math.Cos((i.Azimuth-azimuth)*degrees)
+	return math.Max(cos, 0)
+}
+
+// OnPlane returns the irradiance in W/m² on a plane tilted from horizontal and facing an azimuth in
+// degrees, with the isotropic sky model and light reflected from ground of the albedo
+func (i Irradiance) OnPlane(tilt, azimuth, albedo float64) float64 {
+	if i.GHI <= 0 {
+		return 0
+	}
+	cosTilt := math.Cos(tilt * degrees)
+	return i.DNI*i.Incidence(tilt, azimuth) + i.DHI*(1+cosTilt)/2 + i.GHI*albedo*(1-cosTilt)/2
+}
@@ -0,0 +1,159 @@
+package solar
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+type systemConfig struct {
+	temperatureCoefficient float64
+	losses                 float64
+	albedo                 float64
+	noct                   float64
+}
+
+// Option configures a System
+type Option func(*systemConfig)
+
+// WithTemperatureCoefficient sets the fractional change in power per °C of cell temperature above
+// 25 °C (-0.004 by default, typical of crystalline silicon)
+func WithTemperatureCoefficient(perDegree float64) Option {
+	return func(c *systemConfig) {
+		c.temperatureCoefficient = perDegree
+	}
+}
+
+// WithLosses sets the fraction of power lost to the inverter, wiring and soiling (0.14 by default)
+func WithLosses(fraction float64) Option {
+	return func(c *systemConfig) {
+		c.losses = fraction
+	}
+}
+
+// WithAlbedo sets the reflectance of the ground in front of the panels (0.2 by default)
+func WithAlbedo(albedo float64) Option {
+	return func(c *systemConfig) {
+		c.albedo = albedo
+	}
+}
+
+// WithNOCT sets the nominal operating cell temperature in °C, at 800 W/m² and 20 °C ambient (45 °C by default)
+func WithNOCT(celsius float64) Option {
+	return func(c *systemConfig) {
+		c.noct = celsius
+	}
+}
+
+// System is a photovoltaic system
+type System struct {
+	capacity float64
+	tilt     float64
+	azimuth  float64
+	config   systemConfig
+}
+
+// NewSystem describes a system of a peak capacity in kWp whose panels are tilted from horizontal and
+// face an azimuth clockwise from north, both in degrees (180 faces south)
+func NewSystem(capacity, tilt, azimuth float64, opts ...Option) System {
+	c := systemConfig{temperatureCoefficient: -0.004, losses: 0.14, albedo: 0.2, noct: 45}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return System{capacity: capacity, tilt: tilt, azimuth: azimuth, config: c}
+}
+
+// Output is the estimated output of a system over a period
+type Output struct {
+	Time            time.Time // start of the period
+	Irradiance      Irradiance
+	PlaneIrradiance float64 // W/m² on the panels
+	CellTemperature float64 // °C
+	Power           float64 // kW
+	Energy          float64 // kWh over the period
+}
+
+// Power returns the output in kW at a plane irradiance in W/m² and an ambient temperature in °C
+func (s System) Power(planeIrradiance, temperature float64) (power, cellTemperature float64) {
+	c := s.config
+	cellTemperature = temperature + (c.noct-20)/800*planeIrradiance
+	power = s.capacity * planeIrradiance / 1000 * (1 + c.temperatureCoefficient*(cellTemperature-25)) * (1 - c.losses)
+	return max(power, 0), cellTemperature
+}
+
+// Estimate estimates the output over the period starting at an hourly point at a location, with the
+// sun's position at the middle of the period. It needs the cloud cover; a missing temperature counts as 25 °C.
+func (s System) Estimate(p *models.DataPoint, units string, latitude, longitude float64, period time.Duration) (Output, error) {
+	cloudCover, ok := p.Value("cloudCover")
+	if !ok {
+		return Output{}, fmt.Errorf("%w: cloudCover", meteorology.ErrMissingInput)
+	}
+	temperature := 25.0
+	if value, ok := p.Value("temperature"); ok {
+		var err error
+		if temperature, err = models.ConvertValue(models.QuantityTemperature, value, units, models.UnitsSI); err != nil {
+			return Output{}, err
+		}
+	}
+
+	start := p.LocalTime()
+	irradiance := ClearSky(start.Add(period/2), latitude, longitude).Attenuate(cloudCover)
+	irradiance.Time = start
+	output := Output{
+		Time:            start,
+		Irradiance:      irradiance,
+		PlaneIrradiance: irradiance.OnPlane(s.tilt, s.azimuth, s.config.albedo),
+	}
+	output.Power, output.CellTemperature = s.Power(output.PlaneIrradiance, temperature)
+	output.Energy = output.Power * period.Hours()
+	return output, nil
+}
+
+// Forecast estimates the output over each hour of a forecast's hourly block. Hours without cloud cover are skipped.
+func (s System) Forecast(forecast *models.ForecastResponse) ([]Output, error) {
+	if forecast.Hourly == nil {
+		return nil, models.ErrNoHourly
+	}
+	var outputs []Output
+	for _, p := range forecast.Hourly.Data {
+		p.SetLocation(forecast.Location())
+		output, err := s.Estimate(&p, forecast.Units(), forecast.Latitude, forecast.Longitude, time.Hour)
+		if errors.Is(err, meteorology.ErrMissingInput) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// Production is the estimated output of a calendar day
+type Production struct {
+	Date      time.Time // start of the day
+	Energy    float64   // kWh
+	PeakPower float64   // kW
+	PeakTime  time.Time
+}
+
+// Daily totals outputs by calendar day in their location
+func Daily(outputs []Output) []Production {
+	var days []Production
+	for _, o := range outputs {
+		year, month, day := o.Time.Date()
+		date := time.Date(year, month, day, 0, 0, 0, 0, o.Time.Location())
+		if n := len(days); n == 0 || !days[n-1].Date.Equal(date) {
+			days = append(days, Production{Date: date})
+		}
+		production := &days[len(days)-1]
+		production.Energy += o.Energy
+		if o.Power > production.PeakPower {
+			production.PeakPower, production.PeakTime = o.Power, o.Time
+		}
+	}
+	return days
+}
@@ -0,0 +1,113 @@
+package solar_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/solar"
+	"github.com/stretchr/testify/require"
+)
+
+// Denver on the June solstice: solar noon is at about 19:00 UTC
+const denverLat, denverLon = 39.74, -104.99
+
+var solarNoon = time.Date(2024, 6, 21, 19, 0, 0, 0, time.UTC)
+
+func TestClearSky(t *testing.T) {
+	noon := solar.ClearSky(solarNoon, denverLat, denverLon)
+	require.InDelta(t, 73.7, noon.Elevation, 0.5)
+	require.InDelta(t, 980, noon.GHI, 30)
+	require.InDelta(t, 890, noon.DNI, 40)
+	require.InDelta(t, noon.GHI, noon.DNI*math.Sin(noon.Elevation*math.Pi/180)+noon.DHI, 1e-9)
+	require.Greater(t, noon.DHI, 0.0)
+
+	evening := solar.ClearSky(solarNoon.Add(6*time.Hour), denverLat, denverLon)
+	require.Less(t, evening.GHI, noon.GHI/4)
+
+	night := solar.ClearSky(solarNoon.Add(12*time.Hour), denverLat, denverLon)
+	require.Zero(t, night.GHI)
+	require.Zero(t, night.OnPlane(30, 180, 0.2))
+}
+
+func TestAttenuateAndProject(t *testing.T) {
+	clear := solar.ClearSky(solarNoon, denverLat, denverLon)
+	require.Equal(t, clear, clear.Attenuate(0))
+
+	overcast := clear.Attenuate(1)
+	require.InDelta(t, 0.25*clear.GHI, overcast.GHI, 1e-9)
+	require.InDelta(t, overcast.GHI, overcast.DHI, 1e-9)
+	require.InDelta(t, 0, overcast.DNI, 1e-9)
+
+	partly := clear.Attenuate(0.5)
+	require.Less(t, partly.GHI, clear.GHI)
+	require.Greater(t, partly.DHI, clear.DHI)
+
+	// A horizontal panel receives the global irradiance
+	require.InDelta(t, clear.GHI, clear.OnPlane(0, 180, 0.2), 1e-9)
+	// A panel facing the sun receives all the direct irradiance
+	facing := clear.Incidence(90-clear.Elevation, clear.Azimuth)
+	require.InDelta(t, 1, facing, 1e-9)
+	require.Greater(t, clear.OnPlane(90-clear.Elevation, clear.Azimuth, 0.2), clear.GHI)
+	// A wall facing north receives only diffuse and reflected light at noon
+	require.Zero(t, clear.Incidence(90, 0))
+}
+
+func TestEstimate(t *testing.T) {
+	system := solar.NewSystem(5, 30, 180)
+	p := models.DataPoint{Time: solarNoon.Add(-30 * time.Minute).Unix(), CloudCover: 0, Temperature: 77}
+	output, err := system.Estimate(&p, "us", denverLat, denverLon, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, solarNoon.Add(-30*time.Minute), output.Time.UTC())
+	require.InDelta(t, 25+25.0/800*output.PlaneIrradiance, output.CellTemperature, 1e-9)
+	require.InDelta(t, 3.5, output.Power, 0.5)
+	require.InDelta(t, output.Power, output.Energy, 1e-9)
+
+	// Hotter cells produce less
+	p.Temperature = 104
+	hot, err := system.Estimate(&p, "us", denverLat, denverLon, time.Hour)
+	require.NoError(t, err)
+	require.InDelta(t, output.CellTemperature+15, hot.CellTemperature, 1e-9)
+	require.InDelta(t, output.Power-5*output.PlaneIrradiance/1000*0.004*15*0.86, hot.Power, 1e-9)
+
+	coefficient := solar.NewSystem(5, 30, 180, solar.WithTemperatureCoefficient(0), solar.WithLosses(0))
+	power, _ := coefficient.Power(1000, 40)
+	require.InDelta(t, 5, power, 1e-9)
+
+	p.SetAbsent("cloudCover")
+	_, err = system.Estimate(&p, "us", denverLat, denverLon, time.Hour)
+	require.ErrorIs(t, err, meteorology.ErrMissingInput)
+}
+
+func TestForecast(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	require.NoError(t, err)
+	day := time.Date(2024, 6, 21, 0, 0, 0, 0, denver)
+
+	forecast := func(cloudCover float64) *models.ForecastResponse {
+		f := &models.ForecastResponse{Latitude: denverLat, Longitude: denverLon, Timezone: "America/Denver", Flags: &models.Flags{Units: "si"}, Hourly: &models.DataBlock{}}
+		for i := 0; i < 48; i++ {
+			f.Hourly.Data = append(f.Hourly.Data, models.DataPoint{Time: day.Add(time.Duration(i) * time.Hour).Unix(), CloudCover: cloudCover, Temperature: 25})
+		}
+		return f
+	}
+	system := solar.NewSystem(5, 30, 180)
+
+	outputs, err := system.Forecast(forecast(0))
+	require.NoError(t, err)
+	require.Len(t, outputs, 48)
+	days := solar.Daily(outputs)
+	require.Len(t, days, 2)
+	require.Equal(t, day, days[0].Date)
+	require.InDelta(t, 30, days[0].Energy, 5, "a clear solstice in Denver yields about 6 kWh per kWp")
+	require.WithinDuration(t, solarNoon, days[0].PeakTime.Add(30*time.Minute), 30*time.Minute, "the peak hour spans solar noon")
+
+	overcast, err := system.Forecast(forecast(1))
+	require.NoError(t, err)
+	require.Less(t, solar.Daily(overcast)[0].Energy, days[0].Energy/3)
+
+	_, err = system.Forecast(&models.ForecastResponse{})
+	require.ErrorIs(t, err, models.ErrNoHourly)
+}
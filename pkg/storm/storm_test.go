@@ -0,0 +1,184 @@
+package storm_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/storm"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+
+func pressures(values ...float64) *models.DataBlock {
+	block := &models.DataBlock{}
+	for i, value := range values {
+		block.Data = append(block.Data, models.DataPoint{Time: start.Add(time.Duration(i) * time.Hour).Unix(), Pressure: value})
+	}
+	return block
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		change float64
+		want   storm.TendencyClass
+	}{
+		{0.05, storm.Steady},
+		{-0.05, storm.Steady},
+		{1.2, storm.RisingSlowly},
+		{-2, storm.Falling},
+		{-4.5, storm.FallingQuickly},
+		{6.5, storm.RisingVeryRapidly},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, storm.Classify(tt.change), "%.2f hPa", tt.change)
+	}
+	require.Equal(t, "falling very rapidly", storm.FallingVeryRapidly.String())
+}
+
+func TestPressureTendencies(t *testing.T) {
+	tests := []struct {
+		name           string
+		block          *models.DataBlock
+		change         float64
+		characteristic int
+		class          storm.TendencyClass
+	}{
+		{"steady", pressures(1013, 1013, 1013.05, 1013), 0, 4, storm.Steady},
+		{"falling steadily", pressures(1012, 1011, 1010, 1009), -3, 7, storm.Falling},
+		{"falling faster", pressures(1012, 1011.8, 1011, 1007.5), -4.5, 8, storm.FallingQuickly},
+		{"falling then steady", pressures(1012, 1010, 1009, 1009), -3, 6, storm.Falling},
+		{"falling then rising", pressures(1012, 1011, 1011, 1011.5), -0.5, 5, storm.FallingSlowly},
+		{"rising then falling", pressures(1010, 1012, 1012, 1011), 1, 0, storm.RisingSlowly},
+		{"rising steadily", pressures(1010, 1011, 1012, 1013), 3, 2, storm.Rising},
+		{"rising then steady", pressures(1010, 1012, 1013, 1013), 3, 1, storm.Rising},
+		{"steady then rising", pressures(1010, 1010, 1010, 1012), 2, 3, storm.Rising},
+	}
+	for _, tt := range tests {
+		tendencies, err := storm.PressureTendencies(tt.block)
+		require.NoError(t, err, tt.name)
+		require.Len(t, tendencies, 1, tt.name)
+		got := tendencies[0]
+		require.Equal(t, start.Add(storm.TendencyPeriod), got.Time.UTC(), tt.name)
+		require.InDelta(t, tt.change, got.Change, 1e-9, tt.name)
+		require.Equal(t, tt.characteristic, got.Characteristic, tt.name)
+		require.Equal(t, tt.class, got.Class, tt.name)
+	}
+
+	block := pressures(1015, 1014, 1013, 1012, 1010, 1008)
+	block.Data[4].SetAbsent("pressure")
+	tendencies, err := storm.PressureTendencies(block)
+	require.NoError(t, err)
+	require.Len(t, tendencies, 1, "points with missing pressure in the last three hours are skipped")
+	require.Equal(t, 1012.0, tendencies[0].Pressure)
+
+	_, err = storm.PressureTendencies(pressures(1010, 1011))
+	require.ErrorIs(t, err, storm.ErrInsufficientData)
+}
+
+// polar returns the distance and bearing of a position in km east and north
+func polar(x, y float64) (distance, bearing float64) {
+	return math.Hypot(x, y), math.Mod(math.Atan2(x, y)*180/math.Pi+360, 360)
+}
+
+// approach observes a storm every 10 minutes from 50 km to the SW moving NE at 40 km/h
+func approach(tracker *storm.Tracker, observations int) {
+	for i := 0; i < observations; i++ {
+		minutes := float64(10 * i)
+		tracker.Observe(storm.Observation{
+			Time:     start.Add(time.Duration(minutes) * time.Minute),
+			Distance: 50 - 40*minutes/60,
+			Bearing:  225,
+		})
+	}
+}
+
+func TestTracker(t *testing.T) {
+	tracker := storm.NewTracker(storm.WithRadius(10))
+	_, err := tracker.Motion()
+	require.ErrorIs(t, err, storm.ErrInsufficientData)
+
+	approach(tracker, 4)
+	motion, err := tracker.Motion()
+	require.NoError(t, err)
+	require.InDelta(t, 40, motion.Speed, 1e-6)
+	require.InDelta(t, 45, motion.Heading, 1e-6)
+	require.InDelta(t, 30, motion.Distance, 1e-6)
+	require.True(t, motion.Approaching)
+	require.InDelta(t, 0, motion.ClosestDistance, 1e-6)
+
+	// 20 km to go to the 10 km radius at 40 km/h
+	eta, ok := motion.ETA(start.Add(30 * time.Minute))
+	require.True(t, ok)
+	require.InDelta(t, 30, eta.Minutes(), 1e-6)
+	require.Equal(t, "Storm approaching from the SW, ETA 30 min.", motion.Summary())
+
+	// A storm passing 20 km to the north, moving east
+	tracker = storm.NewTracker(storm.WithRadius(10))
+	for i, x := range []float64{-30, -20, -10} {
+		distance, bearing := polar(x, 20)
+		tracker.Observe(storm.Observation{Time: start.Add(time.Duration(i) * 15 * time.Minute), Distance: distance, Bearing: bearing})
+	}
+	motion, err = tracker.Motion()
+	require.NoError(t, err)
+	require.InDelta(t, 40, motion.Speed, 1e-6)
+	require.InDelta(t, 90, motion.Heading, 1e-6)
+	require.InDelta(t, 20, motion.ClosestDistance, 1e-6)
+	require.Equal(t, start.Add(45*time.Minute), motion.ClosestTime)
+	_, ok = motion.ETA(start)
+	require.False(t, ok)
+	require.Equal(t, "Storm approaching from the NW, passing 20 km away.", motion.Summary())
+}
+
+func TestTrackerRestarts(t *testing.T) {
+	tracker := storm.NewTracker()
+	approach(tracker, 3)
+
+	// A different storm 100 km to the east ten minutes later
+	tracker.Observe(storm.Observation{Time: start.Add(30 * time.Minute), Distance: 100, Bearing: 90})
+	_, err := tracker.Motion()
+	require.ErrorIs(t, err, storm.ErrInsufficientData)
+
+	tracker.Observe(storm.Observation{Time: start.Add(40 * time.Minute), Distance: 105, Bearing: 90})
+	motion, err := tracker.Motion()
+	require.NoError(t, err)
+	require.False(t, motion.Approaching)
+	require.Equal(t, "Storm 105 km to the E, moving away.", motion.Summary())
+
+	// Observations older than the latest are ignored
+	tracker.Observe(storm.Observation{Time: start, Distance: 10, Bearing: 0})
+	motion, err = tracker.Motion()
+	require.NoError(t, err)
+	require.Equal(t, 2, motion.Observations)
+}
+
+func TestObserveForecast(t *testing.T) {
+	tracker := storm.NewTracker()
+	for i := 0; i < 3; i++ {
+		forecast := &models.ForecastResponse{
+			Flags: &models.Flags{Units: "us"},
+			Currently: &models.DataPoint{
+				Time:                 start.Add(time.Duration(i) * 10 * time.Minute).Unix(),
+				NearestStormDistance: 31.0686 - 2.48548*float64(i), // 50 km closing at 24 km/h
+				NearestStormBearing:  180,
+			},
+		}
+		require.NoError(t, tracker.ObserveForecast(forecast))
+	}
+	motion, err := tracker.Motion()
+	require.NoError(t, err)
+	require.InDelta(t, 24, motion.Speed, 0.01)
+	require.InDelta(t, 0, motion.Heading, 0.01)
+	require.InDelta(t, 42, motion.Distance, 0.01)
+
+	// No storm in range resets the track
+	none := &models.ForecastResponse{Flags: &models.Flags{Units: "us"}, Currently: &models.DataPoint{Time: start.Add(time.Hour).Unix()}}
+	none.Currently.SetAbsent("nearestStormDistance")
+	require.NoError(t, tracker.ObserveForecast(none))
+	_, err = tracker.Motion()
+	require.ErrorIs(t, err, storm.ErrInsufficientData)
+
+	require.ErrorIs(t, tracker.ObserveForecast(&models.ForecastResponse{}), storm.ErrInsufficientData)
+}
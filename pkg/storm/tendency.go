@@ -0,0 +1,182 @@
+// Package storm interprets pressure and nearest storm fields over time: the three-hour barometric
+// tendency of an hourly series, and the motion and arrival time of the nearest storm tracked
+// across successive forecasts.
+package storm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/series"
+)
+
+// ErrInsufficientData is returned when there are too few values to analyse
+var ErrInsufficientData = errors.New("insufficient data")
+
+// TendencyPeriod is the period barometric tendency is reported over
+const TendencyPeriod = 3 * time.Hour
+
+// TendencyClass describes the three-hour pressure change with the terms of marine forecasts
+type TendencyClass int
+
+const (
+	Steady TendencyClass = iota
+	RisingSlowly
+	Rising
+	RisingQuickly
+	RisingVeryRapidly
+	FallingSlowly
+	Falling
+	FallingQuickly
+	FallingVeryRapidly
+)
+
+func (c TendencyClass) String() string {
+	switch c {
+	case RisingSlowly:
+		return "rising slowly"
+	case Rising:
+		return "rising"
+	case RisingQuickly:
+		return "rising quickly"
+	case RisingVeryRapidly:
+		return "rising very rapidly"
+	case FallingSlowly:
+		return "falling slowly"
+	case Falling:
+		return "falling"
+	case FallingQuickly:
+		return "falling quickly"
+	case FallingVeryRapidly:
+		return "falling very rapidly"
+	default:
+		return "steady"
+	}
+}
+
+// Classify classifies a three-hour pressure change in hPa: steady below 0.1 hPa, slowly up to
+// 1.5 hPa, then plain up to 3.5 hPa, quickly up to 6 hPa and very rapidly beyond
+func Classify(change float64) TendencyClass {
+	var class TendencyClass
+	switch magnitude := math.Abs(change); {
+	case magnitude < 0.1:
+		return Steady
+	case magnitude <= 1.5:
+		class = RisingSlowly
+	case magnitude <= 3.5:
+		class = Rising
+	case magnitude <= 6:
+		class = RisingQuickly
+	default:
+		class = RisingVeryRapidly
+	}
+	if change < 0 {
+		class += FallingSlowly - RisingSlowly
+	}
+	return class
+}
+
+// Tendency is the barometric tendency over the three hours before Time
+type Tendency struct {
+	Time     time.Time
+	Pressure float64 // hPa at Time
+	Change   float64 // hPa since three hours before Time
+	// Characteristic is the WMO code (table 0200) for the shape of the pressure trace,
+	// 0 to 3 for higher than three hours ago, 4 for steady and 5 to 8 for lower
+	Characteristic int
+	Class          TendencyClass
+}
+
+// steadyChange is the change in hPa below which a half of the period counts as steady
+const steadyChange = 0.1
+
+// characteristic returns the WMO pressure characteristic from the changes over the two halves of the period
+func characteristic(first, second float64) int {
+	net := first + second
+	switch {
+	case math.Abs(net) < steadyChange:
+		switch {
+		case first >= steadyChange && second <= -steadyChange:
+			return 0
+		case first <= -steadyChange && second >= steadyChange:
+			return 5
+		default:
+			return 4
+		}
+	case net > 0:
+		switch {
+		case second <= -steadyChange:
+			return 0 // increasing, then decreasing
+		case first < steadyChange || second > first+steadyChange:
+			return 3 // steady or decreasing then increasing, or increasing more rapidly
+		case second < first-steadyChange:
+			return 1 // increasing then steady, or increasing more slowly
+		default:
+			return 2
+		}
+	default:
+		switch {
+		case second >= steadyChange:
+			return 5 // decreasing, then increasing
+		case first > -steadyChange || second < first-steadyChange:
+			return 8 // steady or increasing then decreasing, or decreasing more rapidly
+		case second > first+steadyChange:
+			return 6 // decreasing then steady, or decreasing more slowly
+		default:
+			return 7
+		}
+	}
+}
+
+// PressureTendency returns the barometric tendency at t from a series whose pressure covers the
+// three hours before t. Pressure is in hPa in every units system.
+func PressureTendency(s *series.Series, t time.Time) (Tendency, error) {
+	var pressures [3]float64
+	for i := range pressures {
+		value, err := s.Value("pressure", t.Add(-TendencyPeriod*time.Duration(2-i)/2))
+		if err != nil {
+			return Tendency{}, err
+		}
+		pressures[i] = value
+	}
+	first, second := pressures[1]-pressures[0], pressures[2]-pressures[1]
+	change := pressures[2] - pressures[0]
+	return Tendency{
+		Time:           t,
+		Pressure:       pressures[2],
+		Change:         change,
+		Characteristic: characteristic(first, second),
+		Class:          Classify(change),
+	}, nil
+}
+
+// PressureTendencies returns the tendency at every point of a block that has three hours of pressure before it.
+// Points where the pressure is missing are skipped.
+func PressureTendencies(block *models.DataBlock) ([]Tendency, error) {
+	s, err := series.New(block)
+	if err != nil {
+		return nil, err
+	}
+	var tendencies []Tendency
+	for _, p := range s.Points() {
+		t := p.LocalTime()
+		if t.Sub(s.Start()) < TendencyPeriod {
+			continue
+		}
+		tendency, err := PressureTendency(s, t)
+		if errors.Is(err, series.ErrMissingValue) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tendencies = append(tendencies, tendency)
+	}
+	if len(tendencies) == 0 {
+		return nil, fmt.Errorf("%w: no point has %s of pressure before it", ErrInsufficientData, TendencyPeriod)
+	}
+	return tendencies, nil
+}
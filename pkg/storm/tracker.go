@@ -0,0 +1,250 @@
+package storm
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// Observation is a position of the nearest storm relative to the forecast location
+type Observation struct {
+	Time     time.Time
+	Distance float64 // km
+	Bearing  float64 // degrees from true north of the direction the storm lies in
+}
+
+// position returns the storm's position in km east and north of the location
+func (o Observation) position() (x, y float64) {
+	bearing := o.Bearing * math.Pi / 180
+	return o.Distance * math.Sin(bearing), o.Distance * math.Cos(bearing)
+}
+
+type trackerConfig struct {
+	radius   float64
+	window   time.Duration
+	maxSpeed float64
+}
+
+// TrackerOption configures a Tracker
+type TrackerOption func(*trackerConfig)
+
+// WithRadius sets the distance in km at which the storm counts as arrived (5 km by default)
+func WithRadius(km float64) TrackerOption {
+	return func(c *trackerConfig) {
+		c.radius = km
+	}
+}
+
+// WithWindow sets how far back observations are used to estimate the motion (2 hours by default)
+func WithWindow(window time.Duration) TrackerOption {
+	return func(c *trackerConfig) {
+		c.window = window
+	}
+}
+
+// WithMaxSpeed sets the speed in km/h above which a jump in position is taken to be a different
+// storm, restarting the track (150 km/h by default)
+func WithMaxSpeed(kmh float64) TrackerOption {
+	return func(c *trackerConfig) {
+		c.maxSpeed = kmh
+	}
+}
+
+// Tracker follows the nearest storm across successive observations, such as the currently
+// point of forecasts polled every few minutes. It is safe for concurrent use.
+type Tracker struct {
+	mu           sync.Mutex
+	config       trackerConfig
+	observations []Observation
+}
+
+// NewTracker creates a Tracker
+func NewTracker(opts ...TrackerOption) *Tracker {
+	c := trackerConfig{radius: 5, window: 2 * time.Hour, maxSpeed: 150}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &Tracker{config: c}
+}
+
+// Observe adds an observation. Observations older than the latest one are ignored, and a position
+// the storm cannot have reached at the maximum speed restarts the track.
+func (t *Tracker) Observe(o Observation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n := len(t.observations); n > 0 {
+		last := t.observations[n-1]
+		elapsed := o.Time.Sub(last.Time).Hours()
+		if elapsed <= 0 {
+			return
+		}
+		x0, y0 := last.position()
+		x1, y1 := o.position()
+		if math.Hypot(x1-x0, y1-y0) > t.config.maxSpeed*elapsed {
+			t.observations = nil
+		}
+	}
+	t.observations = append(t.observations, o)
+
+	// Keep the latest observation and those within the window before it
+	cutoff := o.Time.Add(-t.config.window)
+	first := 0
+	for first < len(t.observations)-1 && t.observations[first].Time.Before(cutoff) {
+		first++
+	}
+	t.observations = t.observations[first:]
+}
+
+// ObserveForecast observes the nearest storm of a forecast's currently point, converting the distance
+// from the forecast's units. A forecast without a storm distance means no storm is in range and resets the track.
+func (t *Tracker) ObserveForecast(forecast *models.ForecastResponse) error {
+	if forecast.Currently == nil {
+		return fmt.Errorf("%w: forecast has no currently point", ErrInsufficientData)
+	}
+	p := forecast.Currently
+	distance, ok := p.Value("nearestStormDistance")
+	if !ok {
+		t.Reset()
+		return nil
+	}
+	distance, err := models.ConvertValue(models.QuantityDistance, distance, forecast.Units(), models.UnitsSI)
+	if err != nil {
+		return err
+	}
+	bearing, _ := p.Value("nearestStormBearing") // absent when the storm is overhead
+	t.Observe(Observation{Time: p.LocalTime(), Distance: distance, Bearing: bearing})
+	return nil
+}
+
+// Reset forgets all observations
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.observations = nil
+}
+
+// Motion is the estimated motion of the tracked storm
+type Motion struct {
+	Time     time.Time // of the latest observation
+	Distance float64   // km at Time
+	Bearing  float64   // degrees at Time
+	Speed    float64   // km/h
+	Heading  float64   // degrees from true north of the direction the storm moves towards
+
+	// Approaching is true while the distance is shrinking. ClosestDistance and ClosestTime are the
+	// closest approach if the storm keeps its motion, and Arrival is when it comes within the
+	// tracker's radius, or the zero time if it passes by.
+	Approaching     bool
+	ClosestDistance float64
+	ClosestTime     time.Time
+	Arrival         time.Time
+
+	Observations int
+}
+
+// Motion estimates the storm's motion by a least-squares fit of its positions over the window
+func (t *Tracker) Motion() (Motion, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := len(t.observations)
+	if n < 2 {
+		return Motion{}, fmt.Errorf("%w: %d storm observations", ErrInsufficientData, n)
+	}
+	latest := t.observations[n-1]
+
+	// Fit x and y against time in hours since the latest observation
+	var sumT, sumX, sumY, sumTT, sumTX, sumTY float64
+	for _, o := range t.observations {
+		h := o.Time.Sub(latest.Time).Hours()
+		x, y := o.position()
+		sumT += h
+		sumX += x
+		sumY += y
+		sumTT += h * h
+		sumTX += h * x
+		sumTY += h * y
+	}
+	count := float64(n)
+	denominator := count*sumTT - sumT*sumT
+	vx := (count*sumTX - sumT*sumX) / denominator
+	vy := (count*sumTY - sumT*sumY) / denominator
+
+	m := Motion{
+		Time:         latest.Time,
+		Distance:     latest.Distance,
+		Bearing:      latest.Bearing,
+		Speed:        math.Hypot(vx, vy),
+		Heading:      math.Mod(math.Atan2(vx, vy)*180/math.Pi+360, 360),
+		Observations: n,
+	}
+
+	x, y := latest.position()
+	speed2 := vx*vx + vy*vy
+	radial := x*vx + y*vy
+	m.Approaching = radial < 0
+	m.ClosestDistance, m.ClosestTime = latest.Distance, latest.Time
+	if m.Approaching {
+		hours := -radial / speed2
+		m.ClosestDistance = math.Hypot(x+vx*hours, y+vy*hours)
+		m.ClosestTime = later(latest.Time, hours)
+	}
+
+	// Solve |p + v·h| = radius for the first h ≥ 0
+	radius := t.config.radius
+	switch c := x*x + y*y - radius*radius; {
+	case c <= 0:
+		m.Arrival = latest.Time
+	case m.Approaching && m.ClosestDistance <= radius:
+		discriminant := radial*radial - speed2*c
+		hours := (-radial - math.Sqrt(discriminant)) / speed2
+		m.Arrival = later(latest.Time, hours)
+	}
+	return m, nil
+}
+
+// later returns the time some hours after t, rounded to the second
+func later(t time.Time, hours float64) time.Time {
+	return t.Add(time.Duration(hours * float64(time.Hour)).Round(time.Second))
+}
+
+// ETA returns the time from now until the storm arrives; ok is false if it is not expected to arrive
+func (m Motion) ETA(now time.Time) (eta time.Duration, ok bool) {
+	if m.Arrival.IsZero() {
+		return 0, false
+	}
+	return max(m.Arrival.Sub(now), 0), true
+}
+
+// Summary describes the motion, e.g. "Storm approaching from the SW, ETA 45 min."
+func (m Motion) Summary() string {
+	from := meteorology.Compass(m.Bearing, meteorology.Compass8)
+	eta, arriving := m.ETA(m.Time)
+	switch {
+	case arriving && eta == 0:
+		return "Storm overhead."
+	case arriving:
+		return fmt.Sprintf("Storm approaching from the %s, ETA %s.", from, formatDuration(eta))
+	case m.Approaching:
+		return fmt.Sprintf("Storm approaching from the %s, passing %.0f km away.", from, m.ClosestDistance)
+	default:
+		return fmt.Sprintf("Storm %.0f km to the %s, moving away.", m.Distance, from)
+	}
+}
+
+// formatDuration formats a duration as "45 min" or "2 h 10 min"
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d h", minutes/60)
+	}
+	return fmt.Sprintf("%d h %d min", minutes/60, minutes%60)
+}
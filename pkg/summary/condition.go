@@ -0,0 +1,83 @@
+package summary
+
+import (
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/nowcast"
+)
+
+// Thresholds in SI units, matching the API's icon rules
+const (
+	precipitationThreshold = 0.1 // mm/h
+	minProbability         = 0.4
+	fogVisibility          = 1.0  // km
+	windySpeed             = 10.0 // m/s
+)
+
+// condition is the weather condition of a data point. text is a message of the i18n catalog,
+// and windy adds "windy and" to it.
+type condition struct {
+	text          string
+	windy         bool
+	precipitating bool
+}
+
+// conditionOf describes a data point, e.g. "light rain" or "windy and partly cloudy".
+// intensityField is the field precipitation is judged by: precipIntensity, or precipIntensityMax for daily points.
+func conditionOf(p *models.DataPoint, units, intensityField string) (condition, error) {
+	si := func(q models.Quantity, name string) (float64, bool, error) {
+		value, ok := p.Value(name)
+		if !ok {
+			return 0, false, nil
+		}
+		value, err := models.ConvertValue(q, value, units, models.UnitsSI)
+		return value, true, err
+	}
+
+	intensity, _ := p.Value(intensityField)
+	probability, hasProbability := p.Value("precipProbability")
+	class, err := nowcast.Classify(intensity, units, precipitationThreshold)
+	if err != nil {
+		return condition{}, err
+	}
+	if class != nowcast.IntensityNone && (!hasProbability || probability >= minProbability) && p.PrecipType != "none" {
+		kind := p.PrecipType
+		if kind == "" {
+			kind = "precipitation"
+		}
+		switch class {
+		case nowcast.IntensityLight:
+			kind = "light " + kind
+		case nowcast.IntensityHeavy:
+			kind = "heavy " + kind
+		}
+		return condition{text: kind, precipitating: true}, nil
+	}
+
+	visibility, hasVisibility, err := si(models.QuantityDistance, "visibility")
+	if err != nil {
+		return condition{}, err
+	}
+	if hasVisibility && visibility < fogVisibility {
+		return condition{text: "foggy"}, nil
+	}
+
+	sky := "clear"
+	if cloudCover, ok := p.Value("cloudCover"); ok {
+		switch {
+		case cloudCover > 0.9375:
+			sky = "overcast"
+		case cloudCover > 0.75:
+			sky = "mostly cloudy"
+		case cloudCover > 0.375:
+			sky = "partly cloudy"
+		}
+	}
+	windSpeed, _, err := si(models.QuantitySpeed, "windSpeed")
+	if err != nil {
+		return condition{}, err
+	}
+	if windSpeed >= windySpeed {
+		return condition{text: sky, windy: true}, nil
+	}
+	return condition{text: sky}, nil
+}
@@ -0,0 +1,61 @@
+package summary
+
+import "github.com/jdotcurs/pirateweather-go/pkg/i18n"
+
+// The default templates are registered under their message IDs, so WithLocale picks up their
+// translations and i18n.Register can add templates for other locales
+func init() {
+	i18n.Register("de", i18n.Catalog{
+		DayTemplateID: `{{.Condition}} {{if and .Precipitating .During}}{{.During}}{{else}}den ganzen Tag{{end}}` +
+			`{{if .HasTemperature}}, Höchstwerte bis {{temp .High}}{{end}}.`,
+
+		MinutelyTemplateID: `{{if not .Precipitating}}Kein Niederschlag in der nächsten Stunde` +
+			`{{else}}{{.Condition}} {{if and .Start .Until}}beginnt {{.Start}}, endet {{.Until}}` +
+			`{{else if .Start}}beginnt {{.Start}}{{else if .Until}}endet {{.Until}}{{else}}in der nächsten Stunde{{end}}{{end}}.`,
+
+		HourlyTemplateID: `{{.Condition}} {{if not .Precipitating}}den ganzen Tag` +
+			`{{else if .During}}{{.During}}{{else if and .Start .Until}}ab {{.Start}}, anhaltend bis {{.Until}}` +
+			`{{else if .Start}}ab {{.Start}}{{else if .Until}}bis {{.Until}}{{else}}den ganzen Tag{{end}}` +
+			`{{if .HasTemperature}}, Höchstwerte bis {{temp .High}}{{end}}.`,
+
+		DailyTemplateID: `{{if .AllDays}}{{.Condition}} die ganze Woche{{else if .Days}}{{.Condition}} {{list .Days}}` +
+			`{{else}}Kein Niederschlag in dieser Woche{{end}}` +
+			`{{if .HasTemperature}}, Höchstwerte bis {{temp .High}} {{.HighTime}}{{end}}.`,
+	})
+
+	i18n.Register("es", i18n.Catalog{
+		DayTemplateID: `{{.Condition}} {{if and .Precipitating .During}}{{.During}}{{else}}durante todo el día{{end}}` +
+			`{{if .HasTemperature}}, con máximas de {{temp .High}}{{end}}.`,
+
+		MinutelyTemplateID: `{{if not .Precipitating}}Sin precipitación durante la próxima hora` +
+			`{{else}}{{.Condition}} {{if and .Start .Until}}comenzando {{.Start}}, terminando {{.Until}}` +
+			`{{else if .Start}}comenzando {{.Start}}{{else if .Until}}terminando {{.Until}}{{else}}durante la próxima hora{{end}}{{end}}.`,
+
+		HourlyTemplateID: `{{.Condition}} {{if not .Precipitating}}durante todo el día` +
+			`{{else if .During}}{{.During}}{{else if and .Start .Until}}desde {{.Start}}, continuando hasta {{.Until}}` +
+			`{{else if .Start}}desde {{.Start}}{{else if .Until}}hasta {{.Until}}{{else}}durante todo el día{{end}}` +
+			`{{if .HasTemperature}}, con máximas de {{temp .High}}{{end}}.`,
+
+		DailyTemplateID: `{{if .AllDays}}{{.Condition}} durante toda la semana{{else if .Days}}{{.Condition}} {{list .Days}}` +
+			`{{else}}Sin precipitación durante la semana{{end}}` +
+			`{{if .HasTemperature}}, con máximas de {{temp .High}} {{.HighTime}}{{end}}.`,
+	})
+
+	i18n.Register("fr", i18n.Catalog{
+		DayTemplateID: `{{.Condition}} {{if and .Precipitating .During}}{{.During}}{{else}}toute la journée{{end}}` +
+			`{{if .HasTemperature}}, maximales de {{temp .High}}{{end}}.`,
+
+		MinutelyTemplateID: `{{if not .Precipitating}}Pas de précipitations dans l'heure` +
+			`{{else}}{{.Condition}} {{if and .Start .Until}}commençant {{.Start}}, cessant {{.Until}}` +
+			`{{else if .Start}}commençant {{.Start}}{{else if .Until}}cessant {{.Until}}{{else}}pendant l'heure{{end}}{{end}}.`,
+
+		HourlyTemplateID: `{{.Condition}} {{if not .Precipitating}}toute la journée` +
+			`{{else if .During}}{{.During}}{{else if and .Start .Until}}à partir de {{.Start}}, jusqu'à {{.Until}}` +
+			`{{else if .Start}}à partir de {{.Start}}{{else if .Until}}jusqu'à {{.Until}}{{else}}toute la journée{{end}}` +
+			`{{if .HasTemperature}}, maximales de {{temp .High}}{{end}}.`,
+
+		DailyTemplateID: `{{if .AllDays}}{{.Condition}} toute la semaine{{else if .Days}}{{.Condition}} {{list .Days}}` +
+			`{{else}}Pas de précipitations cette semaine{{end}}` +
+			`{{if .HasTemperature}}, maximales de {{temp .High}} {{.HighTime}}{{end}}.`,
+	})
+}
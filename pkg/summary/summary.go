@@ -0,0 +1,506 @@
+// Package summary writes Dark Sky style summaries, such as "Light rain until this afternoon,
+// temperatures peaking at 24°.", for data points and blocks the client builds itself.
+// The wording comes from text/template templates that can be replaced.
+package summary
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/i18n"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/nowcast"
+)
+
+// Default templates. Each is executed with a Data value and the result's first letter is capitalized.
+const (
+	DefaultPointTemplate = `{{.Condition}}`
+
+	DefaultDayTemplate = `{{.Condition}} {{if and .Precipitating .During}}{{.During}}{{else}}throughout the day{{end}}` +
+		`{{if .HasTemperature}}, temperatures peaking at {{temp .High}}{{end}}.`
+
+	DefaultMinutelyTemplate = `{{if not .Precipitating}}No precipitation for the hour` +
+		`{{else}}{{.Condition}} {{if and .Start .Until}}starting {{.Start}}, stopping {{.Until}}` +
+		`{{else if .Start}}starting {{.Start}}{{else if .Until}}stopping {{.Until}}{{else}}for the hour{{end}}{{end}}.`
+
+	DefaultHourlyTemplate = `{{.Condition}} {{if not .Precipitating}}throughout the day` +
+		`{{else if .During}}{{.During}}{{else if and .Start .Until}}starting {{.Start}}, continuing until {{.Until}}` +
+		`{{else if .Start}}starting {{.Start}}{{else if .Until}}until {{.Until}}{{else}}throughout the day{{end}}` +
+		`{{if .HasTemperature}}, temperatures peaking at {{temp .High}}{{end}}.`
+
+	DefaultDailyTemplate = `{{if .AllDays}}{{.Condition}} throughout the week{{else if .Days}}{{.Condition}} {{list .Days}}` +
+		`{{else}}No precipitation throughout the week{{end}}` +
+		`{{if .HasTemperature}}, with high temperatures peaking at {{temp .High}} {{.HighTime}}{{end}}.`
+)
+
+// Message IDs of the default templates in the i18n catalog. i18n.Register adds a locale's templates
+// under them, and WithLocale picks them up in place of the defaults.
+const (
+	PointTemplateID    = "summary.template.point"
+	DayTemplateID      = "summary.template.day"
+	MinutelyTemplateID = "summary.template.minutely"
+	HourlyTemplateID   = "summary.template.hourly"
+	DailyTemplateID    = "summary.template.daily"
+)
+
+// Data is what the templates are executed with. Fields that do not apply to a kind of summary are empty.
+type Data struct {
+	// Condition describes the weather, e.g. "light rain" or "windy and partly cloudy". When
+	// Precipitating is true it is the heaviest precipitation of the point or block.
+	Condition     string
+	Precipitating bool
+	Icon          string
+
+	// Start and Until are when the first spell of precipitation in a block starts and stops, such as
+	// "this evening" or "in 12 min". Start is empty when it is already precipitating and Until when it
+	// lasts past the end of the block. During replaces both when the spell fits in one part of a day,
+	// and for daily points is the part of the day of the heaviest precipitation ("in the afternoon").
+	Start  string
+	Until  string
+	During string
+
+	// Days are the days of a daily block with precipitation ("today", "tomorrow", "on Saturday"),
+	// and AllDays reports whether every day has precipitation
+	Days    []string
+	AllDays bool
+
+	// High and Low are the extreme temperatures, and HighTime and LowTime describe when they occur.
+	// HasTemperature is false when the points carry no temperatures.
+	High            float64
+	Low             float64
+	HighTime        string
+	LowTime         string
+	HasTemperature  bool
+	TemperatureUnit string
+	Units           string
+}
+
+// funcs returns the template functions for a locale
+func funcs(locale string) template.FuncMap {
+	return template.FuncMap{
+		// temp formats a temperature rounded to a whole degree, e.g. "24°"
+		"temp": func(t float64) string {
+			return fmt.Sprintf("%.0f°", math.Round(t)+0) // +0 turns -0 into 0
+		},
+		// list joins phrases as "a, b and c", dropping a repeated "on": "on Sunday and Monday"
+		"list": func(items []string) string {
+			items = append([]string(nil), items...)
+			on := strings.TrimSuffix(i18n.Translate(locale, "on %s"), "%s")
+			for i := len(items) - 1; i > 0 && on != ""; i-- {
+				if strings.HasPrefix(items[i], on) && strings.HasPrefix(items[i-1], on) {
+					items[i] = strings.TrimPrefix(items[i], on)
+				}
+			}
+			if len(items) < 2 {
+				return strings.Join(items, "")
+			}
+			return strings.Join(items[:len(items)-1], ", ") + i18n.Translate(locale, " and ") + items[len(items)-1]
+		},
+	}
+}
+
+type config struct {
+	point    string
+	day      string
+	minutely string
+	hourly   string
+	daily    string
+	locale   string
+}
+
+// Option configures a Generator
+type Option func(*config)
+
+// WithPointTemplate sets the template for currently, minutely and hourly points
+func WithPointTemplate(text string) Option {
+	return func(c *config) {
+		c.point = text
+	}
+}
+
+// WithDayTemplate sets the template for daily points
+func WithDayTemplate(text string) Option {
+	return func(c *config) {
+		c.day = text
+	}
+}
+
+// WithMinutelyTemplate sets the template for minutely blocks
+func WithMinutelyTemplate(text string) Option {
+	return func(c *config) {
+		c.minutely = text
+	}
+}
+
+// WithHourlyTemplate sets the template for hourly blocks
+func WithHourlyTemplate(text string) Option {
+	return func(c *config) {
+		c.hourly = text
+	}
+}
+
+// WithDailyTemplate sets the template for daily blocks
+func WithDailyTemplate(text string) Option {
+	return func(c *config) {
+		c.daily = text
+	}
+}
+
+// WithLocale writes summaries in a locale such as "de" or "fr-CA". Conditions and times are translated
+// with the i18n catalog, and templates not set by another option are the translations of the defaults.
+func WithLocale(locale string) Option {
+	return func(c *config) {
+		c.locale = locale
+	}
+}
+
+// Generator writes summaries from a set of templates
+type Generator struct {
+	point    *template.Template
+	day      *template.Template
+	minutely *template.Template
+	hourly   *template.Template
+	daily    *template.Template
+	locale   string
+}
+
+// New creates a Generator, returning an error if a template does not parse
+func New(opts ...Option) (*Generator, error) {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, t := range []struct {
+		text        *string
+		id          string
+		defaultText string
+	}{
+		{&c.point, PointTemplateID, DefaultPointTemplate},
+		{&c.day, DayTemplateID, DefaultDayTemplate},
+		{&c.minutely, MinutelyTemplateID, DefaultMinutelyTemplate},
+		{&c.hourly, HourlyTemplateID, DefaultHourlyTemplate},
+		{&c.daily, DailyTemplateID, DefaultDailyTemplate},
+	} {
+		if *t.text != "" {
+			continue
+		}
+		if translation, ok := i18n.Lookup(c.locale, t.id); ok {
+			*t.text = translation
+		} else {
+			*t.text = t.defaultText
+		}
+	}
+
+	g := &Generator{locale: c.locale}
+	for _, t := range []struct {
+		name   string
+		text   string
+		target **template.Template
+	}{
+		{"point", c.point, &g.point},
+		{"day", c.day, &g.day},
+		{"minutely", c.minutely, &g.minutely},
+		{"hourly", c.hourly, &g.hourly},
+		{"daily", c.daily, &g.daily},
+	} {
+		parsed, err := template.New(t.name).Funcs(funcs(c.locale)).Parse(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s template: %w", t.name, err)
+		}
+		*t.target = parsed
+	}
+	return g, nil
+}
+
+var defaultGenerator, _ = New()
+
+// Point summarizes a data point in the given units system with the default templates
+func Point(p *models.DataPoint, units string) (string, error) {
+	return defaultGenerator.Point(p, units)
+}
+
+// Block summarizes a data block with the default templates
+func Block(b *models.DataBlock, units string, loc *time.Location) (string, error) {
+	return defaultGenerator.Block(b, units, loc)
+}
+
+// Fill sets the missing summaries of a forecast with the default templates
+func Fill(forecast *models.ForecastResponse) error {
+	return defaultGenerator.Fill(forecast)
+}
+
+// Point summarizes a data point in the given units system. Daily points, recognised by a
+// temperature high without an instantaneous temperature, use the day template.
+func (g *Generator) Point(p *models.DataPoint, units string) (string, error) {
+	_, hasTemperature := p.Value("temperature")
+	_, hasHigh := p.Value("temperatureHigh")
+	if hasHigh && !hasTemperature {
+		return g.dayPoint(p, units)
+	}
+
+	c, err := conditionOf(p, units, "precipIntensity")
+	if err != nil {
+		return "", err
+	}
+	data := g.newData(c, p.Icon, units)
+	if temperature, ok := p.Value("temperature"); ok {
+		data.High, data.Low, data.HasTemperature = temperature, temperature, true
+	}
+	return execute(g.point, data)
+}
+
+func (g *Generator) dayPoint(p *models.DataPoint, units string) (string, error) {
+	intensityField := "precipIntensityMax"
+	if _, ok := p.Value(intensityField); !ok {
+		intensityField = "precipIntensity"
+	}
+	c, err := conditionOf(p, units, intensityField)
+	if err != nil {
+		return "", err
+	}
+	data := g.newData(c, p.Icon, units)
+	if t, ok := p.TimeOf("precipIntensityMaxTime"); ok && c.precipitating && t.Unix() != 0 {
+		data.During = during(g.locale, t)
+	}
+	data.High, data.HasTemperature = p.Value("temperatureHigh")
+	data.Low, _ = p.Value("temperatureLow")
+	if t, ok := p.TimeOf("temperatureHighTime"); ok {
+		data.HighTime = during(g.locale, t)
+	}
+	if t, ok := p.TimeOf("temperatureLowTime"); ok {
+		data.LowTime = during(g.locale, t)
+	}
+	return execute(g.day, data)
+}
+
+// Block summarizes a data block whose values are in the given units system. The block's spacing
+// decides whether it is summarized as minutely, hourly or daily; times are described in loc
+// relative to the first point.
+func (g *Generator) Block(b *models.DataBlock, units string, loc *time.Location) (string, error) {
+	if b == nil || len(b.Data) == 0 {
+		return "", models.ErrNoData
+	}
+	if len(b.Data) == 1 {
+		return g.Point(&b.Data[0], units)
+	}
+	switch spacing := b.Data[1].Time - b.Data[0].Time; {
+	case spacing <= 60:
+		return g.minutelyBlock(b, units, loc)
+	case spacing <= 3600:
+		return g.hourlyBlock(b, units, loc)
+	default:
+		return g.dailyBlock(b, units, loc)
+	}
+}
+
+func (g *Generator) minutelyBlock(b *models.DataBlock, units string, loc *time.Location) (string, error) {
+	n, err := nowcast.AnalyzeBlock(b, units, loc, nowcast.WithThreshold(precipitationThreshold), nowcast.WithMinProbability(minProbability))
+	if err != nil {
+		return "", err
+	}
+	data := g.newData(condition{}, b.Icon, units)
+	if !n.Dry() {
+		kind := n.PrecipType
+		if kind == "" {
+			kind = "precipitation"
+		}
+		switch n.PeakClass {
+		case nowcast.IntensityLight:
+			kind = "light " + kind
+		case nowcast.IntensityHeavy:
+			kind = "heavy " + kind
+		}
+		data.Condition, data.Precipitating = i18n.Translate(g.locale, kind), true
+		inMinutes := func(t time.Time) string {
+			return i18n.Sprintf(g.locale, "in %d min", int(t.Sub(n.Start)/time.Minute))
+		}
+		if !n.Onset.IsZero() {
+			data.Start = inMinutes(n.Onset)
+		}
+		if !n.Cessation.IsZero() {
+			data.Until = inMinutes(n.Cessation)
+		}
+	}
+	return execute(g.minutely, data)
+}
+
+// hourlySpan is how many hourly points the hourly summary's condition and temperatures cover
+const hourlySpan = 24
+
+func (g *Generator) hourlyBlock(b *models.DataBlock, units string, loc *time.Location) (string, error) {
+	ref := time.Unix(b.Data[0].Time, 0).In(loc)
+	at := func(i int) time.Time { return time.Unix(b.Data[i].Time, 0).In(loc) }
+
+	conditions := make([]condition, len(b.Data))
+	for i := range b.Data {
+		c, err := conditionOf(&b.Data[i], units, "precipIntensity")
+		if err != nil {
+			return "", err
+		}
+		conditions[i] = c
+	}
+
+	var data Data
+	first := -1
+	for i, c := range conditions {
+		if c.precipitating {
+			first = i
+			break
+		}
+	}
+	if first >= 0 {
+		heaviest, last := first, len(conditions)
+		for i := first; i < len(conditions); i++ {
+			if !conditions[i].precipitating {
+				last = i
+				break
+			}
+			if b.Data[i].PrecipIntensity > b.Data[heaviest].PrecipIntensity {
+				heaviest = i
+			}
+		}
+		data = g.newData(conditions[heaviest], b.Icon, units)
+		if first > 0 {
+			data.Start = when(g.locale, ref, at(first))
+		}
+		if last < len(conditions) {
+			data.Until = when(g.locale, ref, at(last))
+		}
+		if data.Start != "" && data.Start == when(g.locale, ref, at(last-1)) && data.Until != "" {
+			data.During = data.Start
+		}
+	} else {
+		data = g.newData(mostCommon(conditions[:min(hourlySpan, len(conditions))]), b.Icon, units)
+	}
+
+	temperatures(&data, b.Data[:min(hourlySpan, len(b.Data))], "temperature", "temperature", func(i int) string {
+		return when(g.locale, ref, at(i))
+	})
+	return execute(g.hourly, data)
+}
+
+func (g *Generator) dailyBlock(b *models.DataBlock, units string, loc *time.Location) (string, error) {
+	ref := time.Unix(b.Data[0].Time, 0).In(loc)
+	var data Data
+	wettest := -1
+	var wettestCondition condition
+	for i := range b.Data {
+		p := &b.Data[i]
+		c, err := conditionOf(p, units, "precipIntensityMax")
+		if err != nil {
+			return "", err
+		}
+		if !c.precipitating {
+			continue
+		}
+		data.Days = append(data.Days, onDay(g.locale, ref, time.Unix(p.Time, 0).In(loc)))
+		if wettest < 0 || p.PrecipIntensityMax > b.Data[wettest].PrecipIntensityMax {
+			wettest, wettestCondition = i, c
+		}
+	}
+	days := data.Days
+	data = g.newData(wettestCondition, b.Icon, units)
+	data.Days, data.AllDays = days, len(days) == len(b.Data)
+
+	temperatures(&data, b.Data, "temperatureHigh", "temperatureLow", func(i int) string {
+		return onDay(g.locale, ref, time.Unix(b.Data[i].Time, 0).In(loc))
+	})
+	return execute(g.daily, data)
+}
+
+// temperatures sets the highest of the high field and the lowest of the low field over the points
+func temperatures(data *Data, points []models.DataPoint, high, low string, describe func(int) string) {
+	hasLow := false
+	for i := range points {
+		if value, ok := points[i].Value(high); ok && (!data.HasTemperature || value > data.High) {
+			data.High, data.HighTime, data.HasTemperature = value, describe(i), true
+		}
+		if value, ok := points[i].Value(low); ok && (!hasLow || value < data.Low) {
+			data.Low, data.LowTime, hasLow = value, describe(i), true
+		}
+	}
+}
+
+// newData starts the template data of a condition, translating it into the generator's locale
+func (g *Generator) newData(c condition, icon, units string) Data {
+	text := i18n.Translate(g.locale, c.text)
+	if c.windy {
+		text = i18n.Sprintf(g.locale, "windy and %s", text)
+	}
+	return Data{
+		Condition:       text,
+		Precipitating:   c.precipitating,
+		Icon:            icon,
+		Units:           units,
+		TemperatureUnit: models.QuantityTemperature.Unit(units),
+	}
+}
+
+// mostCommon returns the most frequent condition, preferring the earliest on ties
+func mostCommon(conditions []condition) condition {
+	counts := make(map[condition]int)
+	best := conditions[0]
+	for _, c := range conditions {
+		counts[c]++
+		if counts[c] > counts[best] {
+			best = c
+		}
+	}
+	return best
+}
+
+func execute(t *template.Template, data Data) (string, error) {
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return capitalize(sb.String()), nil
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// Fill sets the summary of every point and block of the forecast that has none
+func (g *Generator) Fill(forecast *models.ForecastResponse) error {
+	units, loc := forecast.Units(), forecast.Location()
+	if p := forecast.Currently; p != nil && p.Summary == "" {
+		summary, err := g.Point(p, units)
+		if err != nil {
+			return err
+		}
+		p.Summary = summary
+	}
+	for _, b := range []*models.DataBlock{forecast.Minutely, forecast.Hourly, forecast.Daily} {
+		if b == nil {
+			continue
+		}
+		for i := range b.Data {
+			if b.Data[i].Summary != "" {
+				continue
+			}
+			summary, err := g.Point(&b.Data[i], units)
+			if err != nil {
+				return err
+			}
+			b.Data[i].Summary = summary
+		}
+		if b.Summary == "" && len(b.Data) > 0 {
+			summary, err := g.Block(b, units, loc)
+			if err != nil {
+				return err
+			}
+			b.Summary = summary
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package summary_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/i18n"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/summary"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+// start is a Friday morning
+var start = time.Date(2024, 5, 3, 8, 0, 0, 0, time.UTC)
+
+func rain(intensity float64) models.DataPoint {
+	return models.DataPoint{PrecipIntensity: intensity, PrecipProbability: 0.9, PrecipType: "rain", CloudCover: 1, Visibility: 10}
+}
+
+func dry(cloudCover float64) models.DataPoint {
+	return models.DataPoint{PrecipType: "none", CloudCover: cloudCover, Visibility: 10}
+}
+
+// hourly builds 24 hourly points from start; weather picks each hour's point and temperatures rise to 24 at 15:00
+func hourly(weather func(hour int) models.DataPoint) *models.DataBlock {
+	block := &models.DataBlock{}
+	for i := 0; i < 24; i++ {
+		p := weather(i)
+		p.Time = start.Add(time.Duration(i) * time.Hour).Unix()
+		p.Temperature = 24 - float64((i-7)*(i-7))/4
+		block.Data = append(block.Data, p)
+	}
+	return block
+}
+
+func TestPoint(t *testing.T) {
+	tests := []struct {
+		point models.DataPoint
+		units string
+		want  string
+	}{
+		{rain(0.5), "si", "Light rain"},
+		{rain(0.2), "us", "Rain"},
+		{rain(12), "ca", "Heavy rain"},
+		{models.DataPoint{PrecipIntensity: 1, PrecipProbability: 1, PrecipType: "snow"}, "si", "Light snow"},
+		{models.DataPoint{PrecipIntensity: 1, PrecipProbability: 0.1, PrecipType: "rain", CloudCover: 0.8, Visibility: 10}, "si", "Mostly cloudy"},
+		{dry(0.5), "si", "Partly cloudy"},
+		{dry(1), "si", "Overcast"},
+		{models.DataPoint{WindSpeed: 25, Visibility: 10}, "us", "Windy and clear"},
+		{models.DataPoint{Visibility: 0.5}, "us", "Foggy"},
+	}
+	for _, tt := range tests {
+		got, err := summary.Point(&tt.point, tt.units)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got)
+	}
+
+	p := rain(1)
+	_, err := summary.Point(&p, "metric")
+	require.ErrorIs(t, err, models.ErrUnknownUnits)
+}
+
+func TestDayPoint(t *testing.T) {
+	day := rain(0.1)
+	day.PrecipIntensityMax = 1.5
+	day.PrecipIntensityMaxTime = start.Add(6 * time.Hour).Unix()
+	day.TemperatureHigh = 23.6
+	day.SetAbsent("temperature")
+
+	got, err := summary.Point(&day, "si")
+	require.NoError(t, err)
+	require.Equal(t, "Light rain in the afternoon, temperatures peaking at 24°.", got)
+
+	day = dry(0.1)
+	day.TemperatureHigh = 75
+	day.SetAbsent("temperature")
+	got, err = summary.Point(&day, "us")
+	require.NoError(t, err)
+	require.Equal(t, "Clear throughout the day, temperatures peaking at 75°.", got)
+}
+
+func TestHourlyBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		weather func(hour int) models.DataPoint
+		want    string
+	}{
+		{"until", func(hour int) models.DataPoint {
+			if hour < 6 {
+				return rain(0.5)
+			}
+			return dry(0.5)
+		}, "Light rain until this afternoon, temperatures peaking at 24°."},
+		{"during", func(hour int) models.DataPoint {
+			if hour >= 10 && hour < 13 {
+				return rain(3)
+			}
+			return dry(0.5)
+		}, "Rain this evening, temperatures peaking at 24°."},
+		{"starting", func(hour int) models.DataPoint {
+			if hour >= 14 {
+				return rain(1 + float64(hour-14))
+			}
+			return dry(0.9)
+		}, "Heavy rain starting tonight, temperatures peaking at 24°."},
+		{"starting and continuing", func(hour int) models.DataPoint {
+			if hour >= 4 && hour < 22 {
+				return rain(0.5)
+			}
+			return dry(0.2)
+		}, "Light rain starting this afternoon, continuing until tomorrow morning, temperatures peaking at 24°."},
+		{"dry", func(hour int) models.DataPoint {
+			if hour < 16 {
+				return dry(0.5)
+			}
+			return dry(0)
+		}, "Partly cloudy throughout the day, temperatures peaking at 24°."},
+	}
+	for _, tt := range tests {
+		got, err := summary.Block(hourly(tt.weather), "si", time.UTC)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+func TestDailyBlock(t *testing.T) {
+	block := &models.DataBlock{}
+	for i := 0; i < 7; i++ {
+		day := dry(0.3)
+		if i == 2 || i == 3 {
+			day = rain(0.5)
+			day.PrecipIntensityMax = float64(i) / 2
+		}
+		day.Time = start.AddDate(0, 0, i).Unix()
+		day.TemperatureHigh = 20 + float64(i%5)
+		day.TemperatureLow = 10
+		day.SetAbsent("temperature")
+		block.Data = append(block.Data, day)
+	}
+
+	got, err := summary.Block(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Light rain on Sunday and Monday, with high temperatures peaking at 24° on Tuesday.", got)
+
+	for i := range block.Data {
+		block.Data[i].PrecipIntensityMax, block.Data[i].PrecipProbability, block.Data[i].PrecipType = 20, 0.9, "rain"
+	}
+	got, err = summary.Block(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Heavy rain throughout the week, with high temperatures peaking at 24° on Tuesday.", got)
+
+	_, err = summary.Block(&models.DataBlock{}, "si", time.UTC)
+	require.ErrorIs(t, err, models.ErrNoData)
+}
+
+func TestMinutelyBlock(t *testing.T) {
+	block := &models.DataBlock{}
+	for i := 0; i <= 60; i++ {
+		p := models.DataPoint{Time: start.Add(time.Duration(i) * time.Minute).Unix(), PrecipType: "none"}
+		if i >= 12 && i < 40 {
+			p.PrecipIntensity, p.PrecipProbability, p.PrecipType = 0.02, 1, "rain"
+		}
+		block.Data = append(block.Data, p)
+	}
+	got, err := summary.Block(block, "us", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Light rain starting in 12 min, stopping in 40 min.", got)
+}
+
+func TestCustomTemplates(t *testing.T) {
+	g, err := summary.New(
+		summary.WithPointTemplate(`{{.Condition}}, {{printf "%.1f" .High}}{{.TemperatureUnit}}`),
+		summary.WithHourlyTemplate(`{{if .Precipitating}}Bring an umbrella{{else}}No umbrella needed{{end}}`),
+	)
+	require.NoError(t, err)
+
+	p := rain(0.5)
+	p.Temperature = 12
+	got, err := g.Point(&p, "si")
+	require.NoError(t, err)
+	require.Equal(t, "Light rain, 12.0°C", got)
+
+	got, err = g.Block(hourly(func(int) models.DataPoint { return dry(0) }), "us", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "No umbrella needed", got)
+
+	_, err = summary.New(summary.WithDailyTemplate(`{{.Condition`))
+	require.ErrorContains(t, err, "daily template")
+}
+
+func TestFill(t *testing.T) {
+	forecast := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("us"))
+	forecast.Currently.Summary = ""
+	forecast.Hourly.Summary = ""
+	forecast.Daily.Summary = "Keep me"
+	for i := range forecast.Hourly.Data {
+		forecast.Hourly.Data[i].Summary = ""
+	}
+
+	require.NoError(t, summary.Fill(forecast))
+	require.NotEmpty(t, forecast.Currently.Summary)
+	require.Contains(t, forecast.Hourly.Summary, "temperatures peaking at")
+	require.Equal(t, "Keep me", forecast.Daily.Summary)
+	for _, p := range forecast.Hourly.Data {
+		require.NotEmpty(t, p.Summary)
+	}
+}
+
+func TestLocale(t *testing.T) {
+	german, err := summary.New(summary.WithLocale("de"))
+	require.NoError(t, err)
+
+	got, err := german.Block(hourly(func(hour int) models.DataPoint {
+		if hour >= 4 && hour < 22 {
+			return rain(0.5)
+		}
+		return dry(0.2)
+	}), "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Leichter Regen ab heute Nachmittag, anhaltend bis morgen früh, Höchstwerte bis 24°.", got)
+
+	p := models.DataPoint{WindSpeed: 12, CloudCover: 0.5, Visibility: 10}
+	got, err = german.Point(&p, "si")
+	require.NoError(t, err)
+	require.Equal(t, "Windig und teilweise bewölkt", got)
+
+	block := &models.DataBlock{}
+	for i := 0; i < 7; i++ {
+		day := dry(0.3)
+		if i == 2 || i == 3 {
+			day = rain(0.5)
+			day.PrecipIntensityMax = 1
+		}
+		day.Time = start.AddDate(0, 0, i).Unix()
+		day.TemperatureHigh = 20 + float64(i%5)
+		day.SetAbsent("temperature")
+		block.Data = append(block.Data, day)
+	}
+	spanish, err := summary.New(summary.WithLocale("es"))
+	require.NoError(t, err)
+	got, err = spanish.Block(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Lluvia ligera el domingo y lunes, con máximas de 24° el martes.", got)
+
+	// An explicit template wins over the translated default
+	french, err := summary.New(summary.WithLocale("fr"), summary.WithDailyTemplate(`{{.Condition}} {{list .Days}}`))
+	require.NoError(t, err)
+	got, err = french.Block(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Pluie faible dimanche et lundi", got)
+
+	// Templates of other locales are registered under their message IDs
+	i18n.Register("it", i18n.Catalog{
+		summary.DailyTemplateID: `{{.Condition}} {{list .Days}}.`,
+		"light rain":            "pioggia debole",
+		"on %s":                 "%s",
+		" and ":                 " e ",
+	})
+	italian, err := summary.New(summary.WithLocale("it-CH"))
+	require.NoError(t, err)
+	got, err = italian.Block(block, "si", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, "Pioggia debole domenica e lunedì.", got)
+}
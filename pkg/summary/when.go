@@ -0,0 +1,75 @@
+package summary
+
+import (
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/i18n"
+	"github.com/jdotcurs/pirateweather-go/pkg/utils"
+)
+
+// partOfDay names the part of the day of t; hours before 05:00 belong to the previous night
+func partOfDay(t time.Time) string {
+	switch hour := t.Hour(); {
+	case hour < 5 || hour >= 21:
+		return "night"
+	case hour < 12:
+		return "morning"
+	case hour < 17:
+		return "afternoon"
+	default:
+		return "evening"
+	}
+}
+
+// daysBetween returns the number of calendar days from ref to t in t's location
+func daysBetween(ref, t time.Time) int {
+	y1, m1, d1 := ref.In(t.Location()).Date()
+	y2, m2, d2 := t.Date()
+	return int(time.Date(y2, m2, d2, 12, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 12, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// weekday names the weekday of t in the locale
+func weekday(locale string, t time.Time) string {
+	return utils.Format(t, "Monday", locale)
+}
+
+// when describes t relative to ref in the locale: "this afternoon", "tonight", "tomorrow morning" or "Saturday evening"
+func when(locale string, ref, t time.Time) string {
+	part := partOfDay(t)
+	days := daysBetween(ref, t)
+	if t.Hour() < 5 {
+		days--
+	}
+	switch {
+	case days < 0:
+		return i18n.Translate(locale, "overnight")
+	case days == 0 && part == "night":
+		return i18n.Translate(locale, "tonight")
+	case days == 0:
+		return i18n.Translate(locale, "this "+part)
+	case days == 1:
+		return i18n.Translate(locale, "tomorrow "+part)
+	default:
+		return i18n.Sprintf(locale, "%s "+part, weekday(locale, t.Add(-5*time.Hour)))
+	}
+}
+
+// onDay describes the day of t relative to ref in the locale: "today", "tomorrow" or "on Saturday"
+func onDay(locale string, ref, t time.Time) string {
+	switch daysBetween(ref, t) {
+	case 0:
+		return i18n.Translate(locale, "today")
+	case 1:
+		return i18n.Translate(locale, "tomorrow")
+	default:
+		return i18n.Sprintf(locale, "on %s", weekday(locale, t))
+	}
+}
+
+// during describes the part of a day t falls in, for a daily point: "in the morning", "overnight"
+func during(locale string, t time.Time) string {
+	if part := partOfDay(t); part != "night" {
+		return i18n.Translate(locale, "in the "+part)
+	}
+	return i18n.Translate(locale, "overnight")
+}
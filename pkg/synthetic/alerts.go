@@ -0,0 +1,58 @@
+package synthetic
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// alerts issues alerts for the generated days that cross common warning thresholds (SI units)
+func alerts(days []models.DataPoint, loc *time.Location) []models.Alert {
+	alerts := []models.Alert{}
+	for _, day := range days {
+		begin := time.Unix(day.Time, 0).In(loc)
+		end := begin.AddDate(0, 0, 1)
+
+		switch {
+		case day.TemperatureHigh >= 35:
+			alerts = append(alerts, newAlert("Excessive Heat Warning", models.SeverityWarning, begin, end,
+				fmt.Sprintf("Dangerously hot conditions with temperatures up to %.0f °C.", day.TemperatureHigh)))
+		case day.TemperatureHigh >= 32:
+			alerts = append(alerts, newAlert("Heat Advisory", models.SeverityAdvisory, begin, end,
+				fmt.Sprintf("Hot conditions with temperatures up to %.0f °C.", day.TemperatureHigh)))
+		}
+
+		switch {
+		case day.PrecipType == "snow" && day.PrecipAccumulation >= 15:
+			alerts = append(alerts, newAlert("Winter Storm Warning", models.SeverityWarning, begin, end,
+				fmt.Sprintf("Heavy snow with total accumulations of %.0f cm.", day.PrecipAccumulation)))
+		case day.PrecipType == "rain" && day.PrecipAccumulation >= 5:
+			alerts = append(alerts, newAlert("Flood Watch", models.SeverityWatch, begin, end,
+				fmt.Sprintf("Heavy rain with total amounts of %.0f mm.", day.PrecipAccumulation*10)))
+		}
+
+		if day.WindGust >= 20 {
+			alerts = append(alerts, newAlert("Wind Advisory", models.SeverityAdvisory, begin, end,
+				fmt.Sprintf("Gusts up to %.0f km/h expected.", day.WindGust*3.6)))
+		}
+
+		if day.TemperatureLow <= 0 && day.TemperatureLow > -3 {
+			alerts = append(alerts, newAlert("Frost Advisory", models.SeverityAdvisory, begin.Add(18*time.Hour), end.Add(9*time.Hour),
+				fmt.Sprintf("Temperatures as low as %.0f °C will result in frost formation.", day.TemperatureLow)))
+		}
+	}
+	return alerts
+}
+
+func newAlert(title string, severity models.Severity, begin, end time.Time, description string) models.Alert {
+	return models.Alert{
+		Title:       title,
+		Regions:     []string{"Synthetic"},
+		Severity:    severity,
+		Time:        models.NewTimestamp(begin),
+		Expires:     models.NewTimestamp(end),
+		Description: description,
+		URI:         "https://example.com/alerts/synthetic",
+	}
+}
@@ -0,0 +1,137 @@
+package synthetic
+
+import (
+	"math"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// daily derives n daily points from the hourly series, which must start at dayStart and
+// extend to 06:00 the day after the last one
+func (w *weather) daily(series []models.DataPoint, dayStart time.Time, n int) []models.DataPoint {
+	var days []models.DataPoint
+	for i := 0; i < n; i++ {
+		begin := dayStart.AddDate(0, 0, i)
+		end := begin.AddDate(0, 0, 1)
+		hours := window(series, begin, end)
+		if len(hours) == 0 {
+			continue
+		}
+		day := summarize(hours)
+		day.Time = begin.Unix()
+
+		daytime := window(series, begin.Add(6*time.Hour), begin.Add(18*time.Hour))
+		overnight := window(series, begin.Add(18*time.Hour), end.Add(6*time.Hour))
+		day.Icon = dominantIcon(daytime)
+		day.Summary = summaries[day.Icon]
+
+		high := extreme(daytime, temperature, math.Max)
+		low := extreme(overnight, temperature, math.Min)
+		day.TemperatureHigh, day.TemperatureHighTime = high.Temperature, high.Time
+		day.TemperatureLow, day.TemperatureLowTime = low.Temperature, low.Time
+		apparentHigh := extreme(daytime, apparent, math.Max)
+		apparentLow := extreme(overnight, apparent, math.Min)
+		day.ApparentTemperatureHigh, day.ApparentTemperatureHighTime = apparentHigh.ApparentTemperature, apparentHigh.Time
+		day.ApparentTemperatureLow, day.ApparentTemperatureLowTime = apparentLow.ApparentTemperature, apparentLow.Time
+
+		warmest := extreme(hours, temperature, math.Max)
+		coldest := extreme(hours, temperature, math.Min)
+		day.TemperatureMax, day.TemperatureMaxTime = warmest.Temperature, warmest.Time
+		day.TemperatureMin, day.TemperatureMinTime = coldest.Temperature, coldest.Time
+		apparentMax := extreme(hours, apparent, math.Max)
+		apparentMin := extreme(hours, apparent, math.Min)
+		day.ApparentTemperatureMax, day.ApparentTemperatureMaxTime = apparentMax.ApparentTemperature, apparentMax.Time
+		day.ApparentTemperatureMin, day.ApparentTemperatureMinTime = apparentMin.ApparentTemperature, apparentMin.Time
+
+		wettest := extreme(hours, func(p models.DataPoint) float64 { return p.PrecipIntensity }, math.Max)
+		day.PrecipIntensityMax, day.PrecipIntensityMaxTime = wettest.PrecipIntensity, wettest.Time
+		day.PrecipType = wettest.PrecipType
+
+		if rise, set, ok := astronomy.SunCrossing(begin, w.latitude, w.longitude, astronomy.SunriseElevation); ok {
+			day.SunriseTime, day.SunsetTime = rise.Unix(), set.Unix()
+		}
+		if w.config.version >= 2 {
+			if dawn, dusk, ok := astronomy.SunCrossing(begin, w.latitude, w.longitude, astronomy.CivilTwilightElevation); ok {
+				day.DawnTime, day.DuskTime = dawn.Unix(), dusk.Unix()
+			}
+		}
+		day.MoonPhase = round(astronomy.MoonIllumination(begin.Add(12*time.Hour)).Phase, 2)
+
+		days = append(days, day)
+	}
+	return days
+}
+
+// summarize computes the means, maxima and totals of a day of hourly points
+func summarize(hours []models.DataPoint) models.DataPoint {
+	var day models.DataPoint
+	count := float64(len(hours))
+	for _, p := range hours {
+		day.PrecipIntensity += p.PrecipIntensity / count
+		day.PrecipProbability = math.Max(day.PrecipProbability, p.PrecipProbability)
+		day.PrecipIntensityError += p.PrecipIntensityError / count
+		day.PrecipAccumulation += p.PrecipAccumulation
+		day.DewPoint += p.DewPoint / count
+		day.Humidity += p.Humidity / count
+		day.Pressure += p.Pressure / count
+		day.WindSpeed += p.WindSpeed / count
+		day.WindGust = math.Max(day.WindGust, p.WindGust)
+		day.CloudCover += p.CloudCover / count
+		day.UVIndex = math.Max(day.UVIndex, p.UVIndex)
+		day.Visibility += p.Visibility / count
+		day.Ozone += p.Ozone / count
+		day.Smoke += p.Smoke / count
+		day.FireIndex = math.Max(day.FireIndex, p.FireIndex)
+		day.LiquidAccumulation += p.LiquidAccumulation
+		day.SnowAccumulation += p.SnowAccumulation
+		day.IceAccumulation += p.IceAccumulation
+	}
+
+	day.PrecipIntensity = round(day.PrecipIntensity, 4)
+	day.PrecipIntensityError = round(day.PrecipIntensityError, 4)
+	day.PrecipAccumulation = round(day.PrecipAccumulation, 4)
+	day.DewPoint = round(day.DewPoint, 2)
+	day.Humidity = round(day.Humidity, 2)
+	day.Pressure = round(day.Pressure, 2)
+	day.WindSpeed = round(day.WindSpeed, 2)
+	if bearing, ok := meteorology.MeanBearing(hours); ok {
+		day.WindBearing = math.Round(bearing)
+	}
+	day.CloudCover = round(day.CloudCover, 2)
+	day.Visibility = round(day.Visibility, 2)
+	day.Ozone = round(day.Ozone, 1)
+	day.Smoke = round(day.Smoke, 2)
+	day.LiquidAccumulation = round(day.LiquidAccumulation, 4)
+	day.SnowAccumulation = round(day.SnowAccumulation, 4)
+	day.IceAccumulation = round(day.IceAccumulation, 4)
+	return day
+}
+
+// window returns the points with begin <= time < end
+func window(series []models.DataPoint, begin, end time.Time) []models.DataPoint {
+	var points []models.DataPoint
+	for _, p := range series {
+		if p.Time >= begin.Unix() && p.Time < end.Unix() {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+func temperature(p models.DataPoint) float64 { return p.Temperature }
+
+func apparent(p models.DataPoint) float64 { return p.ApparentTemperature }
+
+// extreme returns the first point whose value is selected by pick (math.Max or math.Min)
+func extreme(points []models.DataPoint, value func(models.DataPoint) float64, pick func(a, b float64) float64) models.DataPoint {
+	var best models.DataPoint
+	for i, p := range points {
+		if i == 0 || (pick(value(p), value(best)) == value(p) && value(p) != value(best)) {
+			best = p
+		}
+	}
+	return best
+}
@@ -0,0 +1,61 @@
+package synthetic
+
+import "github.com/jdotcurs/pirateweather-go/pkg/models"
+
+// dailyOnlyFields are only sent on daily data points
+var dailyOnlyFields = []string{
+	"temperatureHigh", "temperatureHighTime", "temperatureLow", "temperatureLowTime",
+	"apparentTemperatureHigh", "apparentTemperatureHighTime", "apparentTemperatureLow", "apparentTemperatureLowTime",
+	"moonPhase", "precipIntensityMax", "precipIntensityMaxTime", "sunriseTime", "sunsetTime",
+	"temperatureMin", "temperatureMinTime", "temperatureMax", "temperatureMaxTime",
+	"apparentTemperatureMin", "apparentTemperatureMinTime", "apparentTemperatureMax", "apparentTemperatureMaxTime",
+	"dawnTime", "duskTime",
+}
+
+// instantFields are only sent on currently and hourly data points
+var instantFields = []string{"temperature", "apparentTemperature", "nearestStormDistance", "nearestStormBearing"}
+
+// version2Fields are only sent by version 2 of the API
+var version2Fields = []string{"smoke", "fireIndex", "liquidAccumulation", "snowAccumulation", "iceAccumulation", "dawnTime", "duskTime"}
+
+// minutelyFields are the only numeric fields sent on minutely data points
+var minutelyFields = map[string]bool{"time": true, "precipIntensity": true, "precipProbability": true, "precipIntensityError": true}
+
+// markAbsent marks the fields the API would not send for each block as absent,
+// so that they are told apart from real zeros and left out of the JSON encoding
+func markAbsent(forecast *models.ForecastResponse, version int) {
+	instant := func(p *models.DataPoint) {
+		p.SetAbsent(dailyOnlyFields...)
+		if version < 2 {
+			p.SetAbsent(version2Fields...)
+		}
+	}
+
+	if forecast.Currently != nil {
+		instant(forecast.Currently)
+	}
+	if forecast.Hourly != nil {
+		for i := range forecast.Hourly.Data {
+			instant(&forecast.Hourly.Data[i])
+		}
+	}
+	if forecast.Daily != nil {
+		for i := range forecast.Daily.Data {
+			p := &forecast.Daily.Data[i]
+			p.SetAbsent(instantFields...)
+			if version < 2 {
+				p.SetAbsent(version2Fields...)
+			}
+		}
+	}
+	if forecast.Minutely != nil {
+		for i := range forecast.Minutely.Data {
+			p := &forecast.Minutely.Data[i]
+			for _, name := range p.PresentFields() {
+				if !minutelyFields[name] {
+					p.SetAbsent(name)
+				}
+			}
+		}
+	}
+}
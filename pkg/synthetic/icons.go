@@ -0,0 +1,25 @@
+package synthetic
+
+import (
+	"github.com/jdotcurs/pirateweather-go/pkg/icons"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+var summaries = map[string]string{
+	"clear-day":           "Clear",
+	"clear-night":         "Clear",
+	"partly-cloudy-day":   "Partly Cloudy",
+	"partly-cloudy-night": "Partly Cloudy",
+	"cloudy":              "Cloudy",
+	"rain":                "Rain",
+	"snow":                "Snow",
+	"sleet":               "Sleet",
+	"wind":                "Windy",
+	"fog":                 "Foggy",
+}
+
+// icon derives the icon from the SI fields of a data point
+func icon(point models.DataPoint, daytime bool) string {
+	condition, _ := icons.Derive(&point, models.UnitsSI, daytime)
+	return condition.String()
+}
@@ -0,0 +1,267 @@
+// Package synthetic generates realistic, internally consistent forecasts for tests and demos
+package synthetic
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/icons"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+const (
+	defaultMinutes = 61
+	defaultHours   = 48
+	defaultDays    = 8
+)
+
+type config struct {
+	seed     int64
+	seeded   bool
+	units    string
+	timezone string
+	minutes  int
+	hours    int
+	days     int
+	version  int
+	alerts   bool
+}
+
+// Option configures the generated forecast
+type Option func(*config)
+
+// WithSeed sets the random seed. The same seed, location and start time always produce the same forecast.
+// By default the seed is derived from the location.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+// WithUnits sets the units system of the generated values: si (default), us, uk or ca
+func WithUnits(units string) Option {
+	return func(c *config) {
+		c.units = units
+	}
+}
+
+// WithTimezone sets the IANA timezone of the location. By default a fixed Etc/GMT zone is derived from the longitude.
+func WithTimezone(timezone string) Option {
+	return func(c *config) {
+		c.timezone = timezone
+	}
+}
+
+// WithMinutes sets the number of minutely data points; 0 omits the minutely block
+func WithMinutes(n int) Option {
+	return func(c *config) {
+		c.minutes = n
+	}
+}
+
+// WithHours sets the number of hourly data points; 0 omits the hourly block
+func WithHours(n int) Option {
+	return func(c *config) {
+		c.hours = n
+	}
+}
+
+// WithDays sets the number of daily data points; 0 omits the daily block
+func WithDays(n int) Option {
+	return func(c *config) {
+		c.days = n
+	}
+}
+
+// WithVersion sets the API version; version 2 adds smoke, fire index, accumulations and dawn/dusk times
+func WithVersion(version int) Option {
+	return func(c *config) {
+		c.version = version
+	}
+}
+
+// WithAlerts enables or disables alerts derived from the generated weather (enabled by default)
+func WithAlerts(enabled bool) Option {
+	return func(c *config) {
+		c.alerts = enabled
+	}
+}
+
+func newConfig(latitude, longitude float64, options []Option) *config {
+	c := &config{
+		units:   "si",
+		minutes: defaultMinutes,
+		hours:   defaultHours,
+		days:    defaultDays,
+		version: 1,
+		alerts:  true,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if !c.seeded {
+		c.seed = locationSeed(latitude, longitude)
+	}
+	if c.timezone == "" {
+		c.timezone = fmt.Sprintf("Etc/GMT%+d", -int(math.Round(longitude/15)))
+	}
+	return c
+}
+
+// Generate builds a forecast for the location as if requested at start
+func Generate(latitude, longitude float64, start time.Time, options ...Option) *models.ForecastResponse {
+	c := newConfig(latitude, longitude, options)
+	loc := loadLocation(c.timezone, longitude)
+	start = start.In(loc).Truncate(time.Minute)
+
+	dayStart := midnight(start)
+	hourStart := start.Truncate(time.Hour)
+
+	// Hourly data covers every generated day plus the following night, so that daily
+	// highs, lows and overnight values can be derived from it
+	end := hourStart.Add(time.Duration(c.hours) * time.Hour)
+	if dailyEnd := dayStart.AddDate(0, 0, c.days+1).Add(6 * time.Hour); dailyEnd.After(end) {
+		end = dailyEnd
+	}
+
+	w := newWeather(c, latitude, longitude, loc)
+	series := w.hourly(dayStart, end)
+
+	forecast := newResponse(c, latitude, longitude, start, loc)
+	currently := w.at(series, start)
+	forecast.Currently = &currently
+
+	if c.minutes > 0 {
+		forecast.Minutely = w.minutely(series, start, c.minutes)
+	}
+	if c.hours > 0 {
+		var hourly []models.DataPoint
+		for _, point := range series {
+			if point.Time >= hourStart.Unix() && len(hourly) < c.hours {
+				hourly = append(hourly, point)
+			}
+		}
+		forecast.Hourly = newBlock(hourly, period(c.hours))
+	}
+	daily := w.daily(series, dayStart, c.days)
+	if c.days > 0 {
+		forecast.Daily = newBlock(daily, period(24*c.days))
+	}
+	if c.alerts {
+		forecast.Alerts = alerts(daily, loc)
+	}
+
+	convert(forecast, c.units)
+	markAbsent(forecast, c.version)
+	forecast.Localize()
+	return forecast
+}
+
+// TimeMachine builds a historical response like the time machine endpoint: the conditions at t,
+// the 24 hourly points of its local day and a single daily point
+func TimeMachine(latitude, longitude float64, t time.Time, options ...Option) *models.ForecastResponse {
+	c := newConfig(latitude, longitude, options)
+	loc := loadLocation(c.timezone, longitude)
+	t = t.In(loc)
+
+	dayStart := midnight(t)
+	w := newWeather(c, latitude, longitude, loc)
+	series := w.hourly(dayStart, dayStart.AddDate(0, 0, 1).Add(6*time.Hour))
+
+	forecast := newResponse(c, latitude, longitude, t, loc)
+	currently := w.at(series, t)
+	forecast.Currently = &currently
+
+	var hourly []models.DataPoint
+	for _, point := range series {
+		if point.Time < dayStart.AddDate(0, 0, 1).Unix() {
+			hourly = append(hourly, point)
+		}
+	}
+	forecast.Hourly = newBlock(hourly, "the day")
+	forecast.Daily = newBlock(w.daily(series, dayStart, 1), "the day")
+
+	convert(forecast, c.units)
+	markAbsent(forecast, c.version)
+	forecast.Localize()
+	return forecast
+}
+
+func newResponse(c *config, latitude, longitude float64, t time.Time, loc *time.Location) *models.ForecastResponse {
+	_, offset := t.In(loc).Zone()
+	return &models.ForecastResponse{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Timezone:  c.timezone,
+		Offset:    float64(offset) / 3600,
+		Elevation: 100,
+		Flags: &models.Flags{
+			Sources:        []string{"synthetic"},
+			SourceTimes:    map[string]string{"synthetic": t.UTC().Format("2006-01-02 15Z")},
+			NearestStation: 0,
+			Units:          c.units,
+			Version:        fmt.Sprintf("V%d.0-synthetic", c.version),
+		},
+	}
+}
+
+// period describes a block of n hours, e.g. "the next two days" or "the week"
+func period(hours int) string {
+	numbers := []string{"", "", "two", "three", "four", "five", "six"}
+	days := hours / 24
+	switch {
+	case hours%24 != 0:
+		return fmt.Sprintf("the next %d hours", hours)
+	case days == 1:
+		return "the day"
+	case days < len(numbers):
+		return fmt.Sprintf("the next %s days", numbers[days])
+	case days <= 8:
+		return "the week"
+	default:
+		return fmt.Sprintf("the next %d days", days)
+	}
+}
+
+func newBlock(data []models.DataPoint, period string) *models.DataBlock {
+	icon := dominantIcon(data)
+	return &models.DataBlock{
+		Summary: fmt.Sprintf("%s throughout %s.", summaries[icon], period),
+		Icon:    icon,
+		Data:    data,
+	}
+}
+
+// dominantIcon returns the dominant icon of the points (see icons.Dominant), or clear-day if none has one
+func dominantIcon(data []models.DataPoint) string {
+	if condition := icons.Dominant(data); condition != icons.Unknown {
+		return condition.String()
+	}
+	return "clear-day"
+}
+
+func loadLocation(timezone string, longitude float64) *time.Location {
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		return loc
+	}
+	offset := int(math.Round(longitude / 15))
+	return time.FixedZone(timezone, offset*3600)
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func locationSeed(latitude, longitude float64) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%.4f,%.4f", latitude, longitude)
+	return int64(h.Sum64())
+}
+
+func newRand(seed int64, t time.Time) *rand.Rand {
+	return rand.New(rand.NewSource(seed ^ t.Unix()))
+}
@@ -0,0 +1,200 @@
+package synthetic_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+	"github.com/jdotcurs/pirateweather-go/pkg/synthetic"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 7, 15, 10, 30, 0, 0, time.UTC)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	first := synthetic.Generate(45.42, -75.69, start, synthetic.WithSeed(42))
+	second := synthetic.Generate(45.42, -75.69, start, synthetic.WithSeed(42))
+	other := synthetic.Generate(45.42, -75.69, start, synthetic.WithSeed(43))
+
+	require.Equal(t, first, second)
+	require.NotEqual(t, first.Hourly.Data, other.Hourly.Data)
+}
+
+func TestGenerateShape(t *testing.T) {
+	forecast := synthetic.Generate(45.42, -75.69, start,
+		synthetic.WithTimezone("America/Toronto"),
+		synthetic.WithHours(168),
+		synthetic.WithDays(3),
+		synthetic.WithMinutes(0),
+	)
+
+	require.Equal(t, "America/Toronto", forecast.Timezone)
+	require.Equal(t, -4.0, forecast.Offset)
+	require.Equal(t, start.Unix(), forecast.Currently.Time)
+	require.Nil(t, forecast.Minutely)
+	require.Len(t, forecast.Hourly.Data, 168)
+	require.Equal(t, start.Truncate(time.Hour).Unix(), forecast.Hourly.Data[0].Time)
+	require.Len(t, forecast.Daily.Data, 3)
+	require.Equal(t, "si", forecast.Flags.Units)
+	require.True(t, strings.HasSuffix(forecast.Hourly.Summary, " throughout the week."), forecast.Hourly.Summary)
+	require.True(t, strings.HasSuffix(forecast.Daily.Summary, " throughout the next three days."), forecast.Daily.Summary)
+
+	short := synthetic.Generate(45.42, -75.69, start, synthetic.WithHours(12))
+	require.True(t, strings.HasSuffix(short.Hourly.Summary, " throughout the next 12 hours."), short.Hourly.Summary)
+
+	loc, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 7, 15, 0, 0, 0, 0, loc).Unix(), forecast.Daily.Data[0].Time)
+}
+
+func TestGenerateIsInternallyConsistent(t *testing.T) {
+	forecast := synthetic.Generate(45.42, -75.69, start, synthetic.WithTimezone("America/Toronto"), synthetic.WithHours(168), synthetic.WithDays(7))
+
+	for _, point := range forecast.Hourly.Data {
+		require.LessOrEqual(t, point.DewPoint, point.Temperature)
+		require.InDelta(t, magnusHumidity(point.Temperature, point.DewPoint), point.Humidity, 0.02)
+		require.GreaterOrEqual(t, point.WindGust, point.WindSpeed)
+		if point.PrecipIntensity > 0 {
+			require.NotEqual(t, "none", point.PrecipType)
+		} else {
+			require.Equal(t, "none", point.PrecipType)
+		}
+		if point.PrecipType == "snow" {
+			require.LessOrEqual(t, point.Temperature, 0.0)
+		}
+	}
+
+	for _, day := range forecast.Daily.Data {
+		begin := time.Unix(day.Time, 0)
+		if day.Time < forecast.Hourly.Data[0].Time {
+			// The hourly block starts at the current hour, partway through the first day
+			continue
+		}
+		daytime := between(forecast.Hourly.Data, begin.Add(6*time.Hour), begin.Add(18*time.Hour))
+		whole := between(forecast.Hourly.Data, begin, begin.Add(24*time.Hour))
+
+		require.Equal(t, maxTemperature(daytime), day.TemperatureHigh)
+		require.Equal(t, maxTemperature(whole), day.TemperatureMax)
+		require.GreaterOrEqual(t, day.TemperatureMax, day.TemperatureMin)
+		require.GreaterOrEqual(t, day.TemperatureHighTime, begin.Add(6*time.Hour).Unix())
+
+		accumulation := 0.0
+		for _, point := range whole {
+			accumulation += point.PrecipAccumulation
+		}
+		require.InDelta(t, accumulation, day.PrecipAccumulation, 0.001)
+
+		// Ottawa in July: sunrise around 05:30 and sunset around 20:45 local time
+		sunrise := time.Unix(day.SunriseTime, 0).Sub(begin).Hours()
+		sunset := time.Unix(day.SunsetTime, 0).Sub(begin).Hours()
+		require.InDelta(t, 5.5, sunrise, 0.5)
+		require.InDelta(t, 20.75, sunset, 0.5)
+	}
+}
+
+func TestGenerateDiurnalCycle(t *testing.T) {
+	forecast := synthetic.Generate(45.42, -75.69, start, synthetic.WithTimezone("America/Toronto"), synthetic.WithHours(48))
+	for _, point := range forecast.Hourly.Data {
+		hour := time.Unix(point.Time, 0).UTC().Hour()
+		if hour >= 5 && hour <= 8 {
+			// Night in Ottawa
+			require.Zero(t, point.UVIndex)
+			require.NotEqual(t, "clear-day", point.Icon)
+			require.NotEqual(t, "partly-cloudy-day", point.Icon)
+		}
+	}
+}
+
+func TestGenerateUnits(t *testing.T) {
+	si := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("si"))
+	us := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("us"))
+	uk := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("uk"))
+	ca := synthetic.Generate(45.42, -75.69, start, synthetic.WithUnits("ca"))
+
+	require.Equal(t, "us", us.Flags.Units)
+	for i, point := range si.Hourly.Data {
+		require.InDelta(t, point.Temperature*9/5+32, us.Hourly.Data[i].Temperature, 0.01)
+		require.InDelta(t, point.WindSpeed*2.23694, us.Hourly.Data[i].WindSpeed, 0.01)
+		require.InDelta(t, point.Visibility*0.621371, us.Hourly.Data[i].Visibility, 0.01)
+		require.InDelta(t, point.PrecipIntensity/25.4, us.Hourly.Data[i].PrecipIntensity, 0.001)
+
+		require.Equal(t, point.Temperature, uk.Hourly.Data[i].Temperature)
+		require.InDelta(t, point.WindSpeed*2.23694, uk.Hourly.Data[i].WindSpeed, 0.01)
+
+		require.Equal(t, point.Temperature, ca.Hourly.Data[i].Temperature)
+		require.InDelta(t, point.WindSpeed*3.6, ca.Hourly.Data[i].WindSpeed, 0.01)
+		require.Equal(t, point.Visibility, ca.Hourly.Data[i].Visibility)
+	}
+}
+
+func TestGenerateVersion2(t *testing.T) {
+	v1 := synthetic.Generate(45.42, -75.69, start)
+	v2 := synthetic.Generate(45.42, -75.69, start, synthetic.WithVersion(2))
+
+	require.Zero(t, v1.Daily.Data[0].DawnTime)
+	require.NotZero(t, v2.Daily.Data[0].DawnTime)
+	require.Less(t, v2.Daily.Data[0].DawnTime, v2.Daily.Data[0].SunriseTime)
+	require.Greater(t, v2.Daily.Data[0].DuskTime, v2.Daily.Data[0].SunsetTime)
+	require.NotZero(t, v2.Currently.Smoke)
+}
+
+func TestGenerateAlerts(t *testing.T) {
+	// Phoenix in July reliably crosses the heat thresholds
+	forecast := synthetic.Generate(33.45, -112.07, start, synthetic.WithTimezone("America/Phoenix"))
+	require.NotEmpty(t, forecast.Alerts)
+	for _, alert := range forecast.Alerts {
+		require.NotEmpty(t, alert.Title)
+		require.True(t, alert.Expires.After(alert.Time.Time))
+	}
+
+	forecast = synthetic.Generate(33.45, -112.07, start, synthetic.WithAlerts(false))
+	require.Nil(t, forecast.Alerts)
+}
+
+func TestTimeMachine(t *testing.T) {
+	at := time.Date(2021, 5, 3, 15, 0, 0, 0, time.UTC)
+	forecast := synthetic.TimeMachine(45.42, -75.69, at, synthetic.WithTimezone("America/Toronto"), synthetic.WithUnits("us"))
+
+	require.Equal(t, at.Unix(), forecast.Currently.Time)
+	require.Nil(t, forecast.Minutely)
+	require.Len(t, forecast.Hourly.Data, 24)
+	require.Len(t, forecast.Daily.Data, 1)
+	require.Equal(t, forecast.Daily.Data[0].Time, forecast.Hourly.Data[0].Time)
+	require.Equal(t, "us", forecast.Flags.Units)
+}
+
+func magnusHumidity(temperature, dewPoint float64) float64 {
+	const a, b = 17.625, 243.04
+	return math.Exp(a*dewPoint/(b+dewPoint)) / math.Exp(a*temperature/(b+temperature))
+}
+
+func between(points []models.DataPoint, begin, end time.Time) []models.DataPoint {
+	var result []models.DataPoint
+	for _, point := range points {
+		if point.Time >= begin.Unix() && point.Time < end.Unix() {
+			result = append(result, point)
+		}
+	}
+	return result
+}
+
+func maxTemperature(points []models.DataPoint) float64 {
+	result := math.Inf(-1)
+	for _, point := range points {
+		result = math.Max(result, point.Temperature)
+	}
+	return result
+}
+
+func TestGenerateMarksFieldsTheAPIWouldNotSend(t *testing.T) {
+	forecast := synthetic.Generate(45.42, -75.69, start)
+
+	require.Equal(t, models.ValueAbsent, forecast.Currently.State("temperatureHigh"))
+	require.Equal(t, models.ValueAbsent, forecast.Currently.State("smoke"))
+	require.Equal(t, models.ValueAbsent, forecast.Daily.Data[0].State("temperature"))
+	require.Equal(t, models.ValuePresent, forecast.Daily.Data[0].State("temperatureHigh"))
+	require.Equal(t, models.ValueAbsent, forecast.Minutely.Data[0].State("temperature"))
+	require.Equal(t, models.ValuePresent, forecast.Minutely.Data[0].State("precipIntensity"))
+}
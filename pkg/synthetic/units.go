@@ -0,0 +1,43 @@
+package synthetic
+
+import "github.com/jdotcurs/pirateweather-go/pkg/models"
+
+// roundedQuantities gives the decimal places kept for the quantities that change between units systems
+var roundedQuantities = map[models.Quantity]int{
+	models.QuantityTemperature:        2,
+	models.QuantitySpeed:              2,
+	models.QuantityDistance:           2,
+	models.QuantityPrecipIntensity:    4,
+	models.QuantityPrecipAccumulation: 4,
+}
+
+// convert converts a forecast generated in SI units to the requested units system
+func convert(forecast *models.ForecastResponse, units string) {
+	if units == "si" || units == "" {
+		return
+	}
+	if forecast.Currently != nil {
+		convertPoint(forecast.Currently, units)
+	}
+	for _, block := range []*models.DataBlock{forecast.Minutely, forecast.Hourly, forecast.Daily} {
+		if block == nil {
+			continue
+		}
+		for i := range block.Data {
+			convertPoint(&block.Data[i], units)
+		}
+	}
+}
+
+func convertPoint(p *models.DataPoint, units string) {
+	if err := p.ConvertUnits("si", units); err != nil {
+		return
+	}
+	for _, field := range models.Fields() {
+		if places, ok := roundedQuantities[field.Quantity]; ok {
+			if value, ok := field.Value(p); ok {
+				field.SetValue(p, round(value, places))
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package synthetic
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/jdotcurs/pirateweather-go/pkg/astronomy"
+	"github.com/jdotcurs/pirateweather-go/pkg/meteorology"
+	"github.com/jdotcurs/pirateweather-go/pkg/models"
+)
+
+// weather evolves the hourly state of the atmosphere from a seeded random walk.
+// All values are computed in SI units and converted once the forecast is assembled.
+type weather struct {
+	config    *config
+	latitude  float64
+	longitude float64
+	loc       *time.Location
+	rng       *rand.Rand
+
+	pressure    float64
+	cloudCover  float64
+	anomaly     float64
+	dryness     float64
+	windSpeed   float64
+	windBearing float64
+	stormRange  float64
+	stormAngle  float64
+	rainHours   int
+	rainRate    float64
+}
+
+func newWeather(c *config, latitude, longitude float64, loc *time.Location) *weather {
+	return &weather{
+		config:    c,
+		latitude:  latitude,
+		longitude: longitude,
+		loc:       loc,
+	}
+}
+
+// hourly generates one data point per hour in [start, end)
+func (w *weather) hourly(start, end time.Time) []models.DataPoint {
+	w.rng = newRand(w.config.seed, start)
+	w.pressure = 1013 + w.rng.NormFloat64()*6
+	w.cloudCover = w.rng.Float64()
+	w.anomaly = w.rng.NormFloat64() * 2
+	w.dryness = 3 + w.rng.Float64()*6
+	w.windSpeed = 2 + w.rng.Float64()*5
+	w.windBearing = w.rng.Float64() * 360
+	w.stormRange = 50 + w.rng.Float64()*250
+	w.stormAngle = w.rng.Float64() * 360
+
+	var series []models.DataPoint
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		if t.In(w.loc).Hour() == 0 {
+			w.newDay()
+		}
+		series = append(series, w.step(t))
+	}
+	return series
+}
+
+// newDay draws the slowly varying parameters of a new day
+func (w *weather) newDay() {
+	w.anomaly = clamp(w.anomaly*0.7+w.rng.NormFloat64()*1.5, -8, 8)
+	w.dryness = clamp(w.dryness+w.rng.NormFloat64()*1.5, 1, 12)
+}
+
+// step advances the state by one hour and returns the resulting data point
+func (w *weather) step(t time.Time) models.DataPoint {
+	// Pressure drifts back towards the standard atmosphere; low pressure brings clouds
+	w.pressure = clamp(w.pressure+w.rng.NormFloat64()*0.6+0.03*(1013-w.pressure), 975, 1045)
+	target := clamp(0.5+(1013-w.pressure)/25, 0, 1)
+	w.cloudCover = clamp(w.cloudCover+0.15*(target-w.cloudCover)+w.rng.NormFloat64()*0.07, 0, 1)
+
+	w.windSpeed = clamp(w.windSpeed+w.rng.NormFloat64()*0.5+0.05*(4+(1013-w.pressure)/4-w.windSpeed), 0, 30)
+	w.windBearing = math.Mod(w.windBearing+w.rng.NormFloat64()*12+360, 360)
+
+	// Precipitation events start under thick cloud and last a few hours
+	if w.rainHours == 0 && w.cloudCover > 0.75 && w.rng.Float64() < 0.2 {
+		w.rainHours = 2 + w.rng.Intn(7)
+		w.rainRate = 0.3 + w.rng.ExpFloat64()*1.5
+	}
+	intensity := 0.0
+	if w.rainHours > 0 {
+		w.rainHours--
+		w.cloudCover = math.Max(w.cloudCover, 0.9)
+		intensity = math.Max(0.05, w.rainRate*(0.6+0.8*w.rng.Float64()))
+	}
+
+	elevation := astronomy.SunPosition(t, w.latitude, w.longitude).Elevation
+	temperature := w.temperature(t)
+	if intensity > 0 {
+		temperature -= 1.5
+	}
+	dryness := w.dryness
+	if intensity > 0 {
+		dryness = 0.5
+	}
+	dewPoint := math.Min(temperature, w.meanTemperature(t)-dryness)
+	humidity := clamp(meteorology.RelativeHumidity(temperature, dewPoint), 0, 1)
+	gust := w.windSpeed * (1.3 + 0.4*w.rng.Float64())
+	if intensity > 0 {
+		gust += intensity
+	}
+
+	w.stormRange = clamp(w.stormRange+w.rng.NormFloat64()*15, 0, 400)
+	w.stormAngle = math.Mod(w.stormAngle+w.rng.NormFloat64()*10+360, 360)
+	stormDistance := w.stormRange
+	if intensity > 0 {
+		stormDistance = 0
+	}
+
+	visibility := 16.09
+	if intensity > 0 {
+		visibility = clamp(16.09-3*intensity, 1, 16.09)
+	} else if humidity > 0.97 && w.windSpeed < 2 {
+		visibility = 0.8
+	}
+
+	point := models.DataPoint{
+		Time:                 t.Unix(),
+		NearestStormDistance: round(stormDistance, 2),
+		NearestStormBearing:  math.Round(w.stormAngle),
+		PrecipIntensity:      round(intensity, 4),
+		PrecipProbability:    round(precipProbability(intensity, w.cloudCover), 2),
+		PrecipIntensityError: round(intensity*0.2, 4),
+		PrecipType:           precipType(intensity, temperature),
+		Temperature:          round(temperature, 2),
+		ApparentTemperature:  round(meteorology.ApparentTemperature(temperature, humidity, w.windSpeed), 2),
+		DewPoint:             round(dewPoint, 2),
+		Humidity:             round(humidity, 2),
+		Pressure:             round(w.pressure, 2),
+		WindSpeed:            round(w.windSpeed, 2),
+		WindGust:             round(gust, 2),
+		WindBearing:          math.Round(w.windBearing),
+		CloudCover:           round(w.cloudCover, 2),
+		UVIndex:              uvIndex(elevation, w.cloudCover),
+		Visibility:           round(visibility, 2),
+		Ozone:                round(300+20*math.Sin(2*math.Pi*float64(t.YearDay())/365)+w.rng.NormFloat64()*3, 1),
+		PrecipAccumulation:   round(accumulation(intensity, temperature), 4),
+	}
+	if w.config.version >= 2 {
+		point.Smoke = round(math.Max(0, 2+w.rng.NormFloat64()), 2)
+		point.FireIndex = round(fireIndex(temperature, humidity, w.windSpeed, intensity), 2)
+		point.LiquidAccumulation, point.SnowAccumulation, point.IceAccumulation = typedAccumulation(point.PrecipType, intensity, point.PrecipAccumulation)
+	}
+	point.Icon = icon(point, elevation > astronomy.SunriseElevation)
+	point.Summary = summaries[point.Icon]
+	return point
+}
+
+// meanTemperature returns the daily mean temperature in °C from latitude, season and the day's anomaly
+func (w *weather) meanTemperature(t time.Time) float64 {
+	annual := 28 - 0.45*math.Abs(w.latitude)
+	amplitude := 0.35 * math.Abs(w.latitude)
+	phase := math.Cos(2 * math.Pi * float64(t.YearDay()-200) / 365)
+	if w.latitude < 0 {
+		phase = -phase
+	}
+	return annual + amplitude*phase + w.anomaly
+}
+
+// temperature returns the hourly temperature in °C, peaking three hours after solar noon.
+// Clear skies widen the diurnal range.
+func (w *weather) temperature(t time.Time) float64 {
+	amplitude := 2 + 5*(1-w.cloudCover)
+	solarHour := math.Mod(float64(t.UTC().Hour())+float64(t.UTC().Minute())/60+w.longitude/15+48, 24)
+	return w.meanTemperature(t) + amplitude*math.Cos(2*math.Pi*(solarHour-15)/24)
+}
+
+// at returns the conditions at t, taken from the hourly point of that hour
+func (w *weather) at(series []models.DataPoint, t time.Time) models.DataPoint {
+	hour := t.Truncate(time.Hour).Unix()
+	for _, point := range series {
+		if point.Time == hour {
+			point.Time = t.Unix()
+			return point
+		}
+	}
+	return models.DataPoint{Time: t.Unix()}
+}
+
+// minutely interpolates precipitation from the hourly series, with some minute-scale noise
+func (w *weather) minutely(series []models.DataPoint, start time.Time, n int) *models.DataBlock {
+	rng := newRand(w.config.seed, start)
+	var data []models.DataPoint
+	for i := 0; i < n; i++ {
+		t := start.Add(time.Duration(i) * time.Minute)
+		hour := w.at(series, t)
+		next := w.at(series, t.Add(time.Hour))
+		fraction := float64(t.Minute()) / 60
+		intensity := hour.PrecipIntensity + (next.PrecipIntensity-hour.PrecipIntensity)*fraction
+		if intensity > 0 {
+			intensity = math.Max(0, intensity*(1+rng.NormFloat64()*0.1))
+		}
+		data = append(data, models.DataPoint{
+			Time:                 t.Unix(),
+			PrecipIntensity:      round(intensity, 4),
+			PrecipProbability:    round(precipProbability(intensity, hour.CloudCover), 2),
+			PrecipIntensityError: round(intensity*0.2, 4),
+			PrecipType:           precipType(intensity, hour.Temperature),
+		})
+	}
+	icon := "clear-day"
+	if len(data) > 0 {
+		icon = w.at(series, start).Icon
+	}
+	return &models.DataBlock{
+		Summary: summaries[icon] + " for the hour.",
+		Icon:    icon,
+		Data:    data,
+	}
+}
+
+func precipProbability(intensity, cloudCover float64) float64 {
+	if intensity > 0 {
+		return clamp(0.6+intensity/5, 0, 1)
+	}
+	return cloudCover * 0.3
+}
+
+// precipType returns the precipitation type for the surface temperature in °C
+func precipType(intensity, temperature float64) string {
+	switch {
+	case intensity <= 0:
+		return "none"
+	case temperature <= 0:
+		return "snow"
+	case temperature <= 2:
+		return "sleet"
+	default:
+		return "rain"
+	}
+}
+
+// accumulation returns the hourly accumulation in cm from an intensity in mm/h,
+// with snow accumulating at a 10:1 ratio
+func accumulation(intensity, temperature float64) float64 {
+	switch precipType(intensity, temperature) {
+	case "snow":
+		return intensity
+	case "none":
+		return 0
+	default:
+		return intensity / 10
+	}
+}
+
+func typedAccumulation(precipType string, intensity, accumulation float64) (liquid, snow, ice float64) {
+	switch precipType {
+	case "rain":
+		return round(accumulation, 4), 0, 0
+	case "snow":
+		return 0, round(accumulation, 4), 0
+	case "sleet":
+		return 0, 0, round(intensity/10, 4)
+	}
+	return 0, 0, 0
+}
+
+func uvIndex(elevation, cloudCover float64) float64 {
+	if elevation <= 0 {
+		return 0
+	}
+	sin := math.Sin(elevation * math.Pi / 180)
+	return round(12.5*math.Pow(sin, 2.5)*(1-0.7*cloudCover), 1)
+}
+
+func fireIndex(temperature, humidity, windSpeed, intensity float64) float64 {
+	if intensity > 0 {
+		return 0
+	}
+	return math.Max(0, (temperature+10)*(1-humidity)*(1+windSpeed/10)/2)
+}
+
+func clamp(value, low, high float64) float64 {
+	return math.Max(low, math.Min(high, value))
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// localeNames holds the month and weekday names of a locale, indexed like time.Month-1 and time.Weekday,
+// and its decimal and digit group separators
+type localeNames struct {
+	months      [12]string
+	shortMonths [12]string
+	days        [7]string
+	shortDays   [7]string
+	decimal     string
+	group       string
+}
+
+// english is used for the empty locale and locales without names
+var english = localeNames{decimal: ".", group: ","}
+
+var locales = map[string]localeNames{
+	"de": {
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		decimal:     ",",
+		group:       ".",
+	},
+	"es": {
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		decimal:     ",",
+		group:       ".",
+	},
+	"fr": {
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		decimal:     ",",
+		group:       "\u202f",
+	},
+	"it": {
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		decimal:     ",",
+		group:       ".",
+	},
+	"nl": {
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		decimal:     ",",
+		group:       ".",
+	},
+	"pt": {
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		days:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortDays:   [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		decimal:     ",",
+		group:       ".",
+	},
+}
+
+// lookupLocale finds the names for a locale such as "fr", "fr-CA" or "pt_BR"; ok is false for English and unknown locales
+func lookupLocale(locale string) (localeNames, bool) {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	names, ok := locales[language]
+	return names, ok
+}
+
+// Format formats t like t.Format(layout), translating month and weekday names into the locale.
+// Unknown locales and the empty locale format in English.
+func Format(t time.Time, layout, locale string) string {
+	names, ok := lookupLocale(locale)
+	if !ok {
+		return t.Format(layout)
+	}
+
+	// Format the layout piecewise so that translated names are never reinterpreted as layout elements
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(layout); {
+		name, width := "", 0
+		switch {
+		case strings.HasPrefix(layout[i:], "January"):
+			name, width = names.months[t.Month()-1], len("January")
+		case strings.HasPrefix(layout[i:], "Jan"):
+			name, width = names.shortMonths[t.Month()-1], len("Jan")
+		case strings.HasPrefix(layout[i:], "Monday"):
+			name, width = names.days[t.Weekday()], len("Monday")
+		case strings.HasPrefix(layout[i:], "Mon"):
+			name, width = names.shortDays[t.Weekday()], len("Mon")
+		default:
+			i++
+			continue
+		}
+		b.WriteString(t.Format(layout[start:i]))
+		b.WriteString(name)
+		i += width
+		start = i
+	}
+	b.WriteString(t.Format(layout[start:]))
+	return b.String()
+}
+
+// FormatNumber formats a value with the given number of decimals using the decimal and digit group
+// separators of the locale, e.g. "1,234.5" in English, "1.234,5" in German and "1 234,5" in French
+func FormatNumber(value float64, decimals int, locale string) string {
+	names, ok := lookupLocale(locale)
+	if !ok {
+		names = english
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	text := strconv.FormatFloat(value, 'f', decimals, 64)
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	whole, fraction, _ := strings.Cut(text, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(names.group)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(names.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
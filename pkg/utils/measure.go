@@ -0,0 +1,92 @@
+package utils
+
+import "strconv"
+
+// dimension is implemented by the marker types that give each Measure its dimension
+type dimension interface {
+	dimension() Dimension
+}
+
+type temperature struct{}
+type speed struct{}
+type length struct{}
+type pressure struct{}
+type precipitationRate struct{}
+
+func (temperature) dimension() Dimension       { return DimensionTemperature }
+func (speed) dimension() Dimension             { return DimensionSpeed }
+func (length) dimension() Dimension            { return DimensionLength }
+func (pressure) dimension() Dimension          { return DimensionPressure }
+func (precipitationRate) dimension() Dimension { return DimensionPrecipitationRate }
+
+// Measure is a value with a unit of the dimension D. Its type keeps, say, a Speed from
+// being passed where a Temperature is expected.
+type Measure[D dimension] struct {
+	Value float64
+	Unit  Unit
+}
+
+type (
+	Temperature       = Measure[temperature]
+	Speed             = Measure[speed]
+	Length            = Measure[length]
+	Pressure          = Measure[pressure]
+	PrecipitationRate = Measure[precipitationRate]
+)
+
+// NewTemperature returns a temperature in the unit, e.g. "°C", "F" or "K"
+func NewTemperature(value float64, unit string) (Temperature, error) {
+	return newMeasure[temperature](value, unit)
+}
+
+// NewSpeed returns a speed in the unit, e.g. "m/s", "kph" or "knots"
+func NewSpeed(value float64, unit string) (Speed, error) {
+	return newMeasure[speed](value, unit)
+}
+
+// NewLength returns a length or distance in the unit, e.g. "mm", "km" or "mi"
+func NewLength(value float64, unit string) (Length, error) {
+	return newMeasure[length](value, unit)
+}
+
+// NewPressure returns a pressure in the unit, e.g. "hPa", "kPa" or "inHg"
+func NewPressure(value float64, unit string) (Pressure, error) {
+	return newMeasure[pressure](value, unit)
+}
+
+// NewPrecipitationRate returns a precipitation rate in the unit, "mm/h" or "in/h"
+func NewPrecipitationRate(value float64, unit string) (PrecipitationRate, error) {
+	return newMeasure[precipitationRate](value, unit)
+}
+
+func newMeasure[D dimension](value float64, unit string) (Measure[D], error) {
+	u, err := LookupUnit(unit)
+	if err != nil {
+		return Measure[D]{}, err
+	}
+	var d D
+	if u.Dimension != d.dimension() {
+		return Measure[D]{}, &DimensionError{From: u, To: Unit{Dimension: d.dimension()}}
+	}
+	return Measure[D]{Value: value, Unit: u}, nil
+}
+
+// In converts the measure to another unit of its dimension
+func (m Measure[D]) In(unit string) (Measure[D], error) {
+	converted, err := newMeasure[D](0, unit)
+	if err != nil {
+		return Measure[D]{}, err
+	}
+	converted.Value, err = ConvertUnit(m.Value, m.Unit.Symbol, converted.Unit.Symbol)
+	return converted, err
+}
+
+// Format formats the value with the given number of decimals followed by the unit symbol, e.g. "21.5 °C"
+func (m Measure[D]) Format(decimals int) string {
+	return strconv.FormatFloat(m.Value, 'f', decimals, 64) + " " + m.Unit.Symbol
+}
+
+// String formats the measure with one decimal
+func (m Measure[D]) String() string {
+	return m.Format(1)
+}
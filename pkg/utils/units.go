@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dimension is the physical dimension of a unit; only units of the same dimension convert into each other
+type Dimension string
+
+const (
+	DimensionTemperature       Dimension = "temperature"
+	DimensionSpeed             Dimension = "speed"
+	DimensionLength            Dimension = "length"
+	DimensionPressure          Dimension = "pressure"
+	DimensionPrecipitationRate Dimension = "precipitation rate"
+)
+
+// Unit is a registered unit of measurement
+type Unit struct {
+	Symbol    string
+	Name      string
+	Dimension Dimension
+	Aliases   []string
+}
+
+// ErrNoConversion is returned when no chain of registered conversions links two units of the same dimension
+var ErrNoConversion = errors.New("no conversion")
+
+// UnknownUnitError is returned for a unit that is not registered
+type UnknownUnitError struct {
+	Unit string
+}
+
+func (e *UnknownUnitError) Error() string {
+	return fmt.Sprintf("unknown unit: %s", e.Unit)
+}
+
+// MessageFormat returns the format and arguments i18n.Error translates
+func (e *UnknownUnitError) MessageFormat() (string, []any) {
+	return "unknown unit: %s", []any{e.Unit}
+}
+
+// DimensionError is returned when converting between units of different dimensions
+type DimensionError struct {
+	From Unit
+	To   Unit
+}
+
+func (e *DimensionError) Error() string {
+	format, args := e.MessageFormat()
+	return fmt.Sprintf(format, args...)
+}
+
+// MessageFormat returns the format and arguments i18n.Error translates, with the dimensions as strings
+func (e *DimensionError) MessageFormat() (string, []any) {
+	if e.To.Symbol == "" {
+		return "%s (%s) is not a %s unit", []any{e.From.Symbol, string(e.From.Dimension), string(e.To.Dimension)}
+	}
+	return "cannot convert %s (%s) to %s (%s)", []any{e.From.Symbol, string(e.From.Dimension), e.To.Symbol, string(e.To.Dimension)}
+}
+
+// conversion is a direct conversion from one unit to another
+type conversion struct {
+	to      string
+	convert func(float64) float64
+}
+
+type registry struct {
+	mu          sync.RWMutex
+	units       map[string]Unit
+	names       map[string]string
+	conversions map[string][]conversion
+}
+
+var units = &registry{
+	units:       make(map[string]Unit),
+	names:       make(map[string]string),
+	conversions: make(map[string][]conversion),
+}
+
+func init() {
+	for _, u := range []Unit{
+		{Symbol: "°C", Name: "degree Celsius", Dimension: DimensionTemperature, Aliases: []string{"C", "celsius", "degC"}},
+		{Symbol: "°F", Name: "degree Fahrenheit", Dimension: DimensionTemperature, Aliases: []string{"F", "fahrenheit", "degF"}},
+		{Symbol: "K", Name: "kelvin", Dimension: DimensionTemperature, Aliases: []string{"kelvin"}},
+
+		{Symbol: "m/s", Name: "metre per second", Dimension: DimensionSpeed, Aliases: []string{"mps"}},
+		{Symbol: "km/h", Name: "kilometre per hour", Dimension: DimensionSpeed, Aliases: []string{"kph", "kmh", "km/hr"}},
+		{Symbol: "mph", Name: "mile per hour", Dimension: DimensionSpeed, Aliases: []string{"mi/h"}},
+		{Symbol: "kn", Name: "knot", Dimension: DimensionSpeed, Aliases: []string{"kt", "kts", "knot", "knots"}},
+
+		{Symbol: "mm", Name: "millimetre", Dimension: DimensionLength},
+		{Symbol: "cm", Name: "centimetre", Dimension: DimensionLength},
+		{Symbol: "m", Name: "metre", Dimension: DimensionLength},
+		{Symbol: "km", Name: "kilometre", Dimension: DimensionLength},
+		{Symbol: "in", Name: "inch", Dimension: DimensionLength, Aliases: []string{"inch", "inches"}},
+		{Symbol: "ft", Name: "foot", Dimension: DimensionLength, Aliases: []string{"foot", "feet"}},
+		{Symbol: "mi", Name: "mile", Dimension: DimensionLength, Aliases: []string{"mile", "miles"}},
+
+		{Symbol: "hPa", Name: "hectopascal", Dimension: DimensionPressure, Aliases: []string{"mbar", "mb", "millibar"}},
+		{Symbol: "Pa", Name: "pascal", Dimension: DimensionPressure},
+		{Symbol: "kPa", Name: "kilopascal", Dimension: DimensionPressure},
+		{Symbol: "inHg", Name: "inch of mercury", Dimension: DimensionPressure},
+		{Symbol: "mmHg", Name: "millimetre of mercury", Dimension: DimensionPressure, Aliases: []string{"torr"}},
+
+		{Symbol: "mm/h", Name: "millimetre per hour", Dimension: DimensionPrecipitationRate, Aliases: []string{"mm/hr"}},
+		{Symbol: "in/h", Name: "inch per hour", Dimension: DimensionPrecipitationRate, Aliases: []string{"in/hr"}},
+	} {
+		RegisterUnit(u)
+	}
+
+	RegisterConversion("°C", "°F", func(c float64) float64 { return c*9/5 + 32 }, func(f float64) float64 { return (f - 32) * 5 / 9 })
+	RegisterConversion("°C", "K", func(c float64) float64 { return c + 273.15 }, func(k float64) float64 { return k - 273.15 })
+
+	RegisterScale("m/s", "km/h", 3.6)
+	RegisterScale("mph", "km/h", 1.609344)
+	RegisterScale("kn", "m/s", 1852.0/3600)
+
+	RegisterScale("cm", "mm", 10)
+	RegisterScale("m", "cm", 100)
+	RegisterScale("km", "m", 1000)
+	RegisterScale("in", "cm", 2.54)
+	RegisterScale("ft", "in", 12)
+	RegisterScale("mi", "km", 1.609344)
+
+	RegisterScale("hPa", "Pa", 100)
+	RegisterScale("kPa", "hPa", 10)
+	RegisterScale("inHg", "hPa", 33.8639)
+	RegisterScale("mmHg", "hPa", 1.333224)
+
+	RegisterScale("in/h", "mm/h", 25.4)
+}
+
+// RegisterUnit adds a unit to the registry, replacing any unit with the same symbol
+func RegisterUnit(u Unit) {
+	units.mu.Lock()
+	defer units.mu.Unlock()
+	units.units[u.Symbol] = u
+	units.names[strings.ToLower(u.Symbol)] = u.Symbol
+	for _, alias := range u.Aliases {
+		units.names[strings.ToLower(alias)] = u.Symbol
+	}
+}
+
+// RegisterConversion adds a direct conversion between two registered units and its inverse.
+// Conversions between other units of the dimension are chained through it.
+func RegisterConversion(from, to string, forward, backward func(float64) float64) {
+	units.mu.Lock()
+	defer units.mu.Unlock()
+	units.conversions[from] = append(units.conversions[from], conversion{to: to, convert: forward})
+	units.conversions[to] = append(units.conversions[to], conversion{to: from, convert: backward})
+}
+
+// RegisterScale adds a conversion where one from unit is factor to units
+func RegisterScale(from, to string, factor float64) {
+	RegisterConversion(from, to,
+		func(v float64) float64 { return v * factor },
+		func(v float64) float64 { return v / factor })
+}
+
+// LookupUnit finds a unit by symbol or alias, ignoring case, e.g. "kph" returns km/h
+func LookupUnit(name string) (Unit, error) {
+	units.mu.RLock()
+	defer units.mu.RUnlock()
+	return units.lookup(name)
+}
+
+// UnitsOf returns the registered units of the dimension, sorted by symbol
+func UnitsOf(dimension Dimension) []Unit {
+	units.mu.RLock()
+	defer units.mu.RUnlock()
+	var result []Unit
+	for _, u := range units.units {
+		if u.Dimension == dimension {
+			result = append(result, u)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+	return result
+}
+
+// ConvertUnit converts value between units of the same dimension, chaining registered conversions
+// when there is no direct one (e.g. m/s to mph through km/h). It returns an *UnknownUnitError for
+// unregistered units and a *DimensionError when the units measure different things.
+func ConvertUnit(value float64, fromUnit, toUnit string) (float64, error) {
+	units.mu.RLock()
+	defer units.mu.RUnlock()
+
+	from, err := units.lookup(fromUnit)
+	if err != nil {
+		return 0, err
+	}
+	to, err := units.lookup(toUnit)
+	if err != nil {
+		return 0, err
+	}
+	if from.Dimension != to.Dimension {
+		return 0, &DimensionError{From: from, To: to}
+	}
+	if from.Symbol == to.Symbol {
+		return value, nil
+	}
+
+	path := units.path(from.Symbol, to.Symbol)
+	if path == nil {
+		return 0, fmt.Errorf("%w: %s to %s", ErrNoConversion, from.Symbol, to.Symbol)
+	}
+	for _, step := range path {
+		value = step(value)
+	}
+	return value, nil
+}
+
+func (r *registry) lookup(name string) (Unit, error) {
+	if u, ok := r.units[name]; ok {
+		return u, nil
+	}
+	if symbol, ok := r.names[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return r.units[symbol], nil
+	}
+	return Unit{}, &UnknownUnitError{Unit: name}
+}
+
+// path finds the shortest chain of conversions from one unit to another with a breadth-first search
+func (r *registry) path(from, to string) []func(float64) float64 {
+	type step struct {
+		previous string
+		convert  func(float64) float64
+	}
+	visited := map[string]step{from: {}}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []func(float64) float64
+			for unit := to; unit != from; unit = visited[unit].previous {
+				path = append([]func(float64) float64{visited[unit].convert}, path...)
+			}
+			return path
+		}
+		for _, c := range r.conversions[current] {
+			if _, seen := visited[c.to]; !seen {
+				visited[c.to] = step{previous: current, convert: c.convert}
+				queue = append(queue, c.to)
+			}
+		}
+	}
+	return nil
+}
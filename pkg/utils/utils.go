@@ -1,17 +1,55 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
-// FormatTime formats a Unix timestamp to a human-readable string
-func FormatTime(timestamp int64) string {
-	t := time.Unix(timestamp, 0)
-	return t.Format(time.RFC3339)
+// ErrUnsupportedConversion is returned by ConvertTemperature for units other than C and F
+var ErrUnsupportedConversion = errors.New("unsupported temperature conversion")
+
+// FormatOption configures FormatTime
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	layout string
+	loc    *time.Location
+	locale string
+}
+
+// WithLayout sets the time.Format layout (RFC3339 by default)
+func WithLayout(layout string) FormatOption {
+	return func(c *formatConfig) {
+		c.layout = layout
+	}
 }
 
-// ConvertTemperature converts temperature between Celsius and Fahrenheit
+// WithLocation formats the time in loc instead of the local zone, e.g. a forecast's Location()
+func WithLocation(loc *time.Location) FormatOption {
+	return func(c *formatConfig) {
+		c.loc = loc
+	}
+}
+
+// WithLocale translates month and weekday names into the locale, e.g. "fr" or "de-AT"
+func WithLocale(locale string) FormatOption {
+	return func(c *formatConfig) {
+		c.locale = locale
+	}
+}
+
+// FormatTime formats a Unix timestamp to a human-readable string.
+// Without options it uses RFC3339 in the local zone.
+func FormatTime(timestamp int64, options ...FormatOption) string {
+	c := &formatConfig{layout: time.RFC3339, loc: time.Local}
+	for _, option := range options {
+		option(c)
+	}
+	return Format(time.Unix(timestamp, 0).In(c.loc), c.layout, c.locale)
+}
+
+// ConvertTemperature converts temperature between Celsius and Fahrenheit; use ConvertUnit for kelvin
 func ConvertTemperature(temp float64, fromUnit, toUnit string) (float64, error) {
 	if fromUnit == toUnit {
 		return temp, nil
@@ -23,30 +61,6 @@ func ConvertTemperature(temp float64, fromUnit, toUnit string) (float64, error)
 	case fromUnit == "F" && toUnit == "C":
 		return (temp - 32) * 5 / 9, nil
 	default:
-		return 0, fmt.Errorf("unsupported temperature conversion: %s to %s", fromUnit, toUnit)
-	}
-}
-
-// ConvertUnit converts between different units of measurement
-func ConvertUnit(value float64, fromUnit, toUnit string) (float64, error) {
-	switch {
-	case fromUnit == "km" && toUnit == "mi":
-		return value * 0.621371, nil
-	case fromUnit == "mi" && toUnit == "km":
-		return value * 1.60934, nil
-	case fromUnit == "m/s" && toUnit == "km/h":
-		return value * 3.6, nil
-	case fromUnit == "km/h" && toUnit == "m/s":
-		return value / 3.6, nil
-	case fromUnit == "km/h" && toUnit == "mph":
-		return value * 0.621371, nil
-	case fromUnit == "mph" && toUnit == "km/h":
-		return value * 1.60934, nil
-	case fromUnit == "hPa" && toUnit == "inHg":
-		return value * 0.02953, nil
-	case fromUnit == "inHg" && toUnit == "hPa":
-		return value / 0.02953, nil
-	default:
-		return 0, fmt.Errorf("unsupported unit conversion: %s to %s", fromUnit, toUnit)
+		return 0, fmt.Errorf("%w: %s to %s", ErrUnsupportedConversion, fromUnit, toUnit)
 	}
 }
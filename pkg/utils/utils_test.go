@@ -1,7 +1,9 @@
 package utils_test
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/jdotcurs/pirateweather-go/pkg/utils"
 	"github.com/stretchr/testify/require"
@@ -64,3 +66,109 @@ func TestConvertUnit(t *testing.T) {
 	_, err := utils.ConvertUnit(1, "kg", "lb")
 	require.Error(t, err)
 }
+
+func TestFormatTimeWithOptions(t *testing.T) {
+	timestamp := int64(1620000000) // 2021-05-03T00:00:00Z, a Monday
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	result := utils.FormatTime(timestamp, utils.WithLocation(paris))
+	require.Equal(t, "2021-05-03T02:00:00+02:00", result)
+
+	result = utils.FormatTime(timestamp, utils.WithLocation(paris), utils.WithLayout("Monday 2 January 15:04"))
+	require.Equal(t, "Monday 3 May 02:00", result)
+
+	result = utils.FormatTime(timestamp, utils.WithLocation(paris), utils.WithLayout("Monday 2 January 15:04"), utils.WithLocale("fr-FR"))
+	require.Equal(t, "lundi 3 mai 02:00", result)
+
+	result = utils.FormatTime(timestamp, utils.WithLocation(time.UTC), utils.WithLayout("Mon, 02 Jan 2006"), utils.WithLocale("de"))
+	require.Equal(t, "Mo, 03 Mai 2021", result)
+
+	result = utils.FormatTime(timestamp, utils.WithLocation(time.UTC), utils.WithLayout("Jan 2"), utils.WithLocale("xx"))
+	require.Equal(t, "May 3", result)
+}
+
+func TestConvertUnitChainsConversions(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		fromUnit string
+		toUnit   string
+		expected float64
+	}{
+		{10, "m/s", "mph", 22.3694},
+		{10, "kph", "km/h", 10},
+		{20, "knots", "km/h", 37.04},
+		{25.4, "mm", "in", 1},
+		{1, "mi", "m", 1609.344},
+		{0, "C", "K", 273.15},
+		{300, "K", "F", 80.33},
+		{1013.25, "hPa", "mmHg", 760},
+		{1013.25, "mbar", "kPa", 101.325},
+		{29.92, "inHg", "kPa", 101.32},
+		{1, "in/h", "mm/hr", 25.4},
+	}
+
+	for _, tc := range testCases {
+		result, err := utils.ConvertUnit(tc.value, tc.fromUnit, tc.toUnit)
+		require.NoError(t, err)
+		require.InDelta(t, tc.expected, result, 0.01, "%s to %s", tc.fromUnit, tc.toUnit)
+	}
+}
+
+func TestConvertUnitErrors(t *testing.T) {
+	_, err := utils.ConvertUnit(1, "km/h", "hPa")
+	var dimensionErr *utils.DimensionError
+	require.ErrorAs(t, err, &dimensionErr)
+	require.Equal(t, utils.DimensionSpeed, dimensionErr.From.Dimension)
+	require.Equal(t, utils.DimensionPressure, dimensionErr.To.Dimension)
+
+	_, err = utils.ConvertUnit(1, "furlong", "km")
+	var unknownErr *utils.UnknownUnitError
+	require.ErrorAs(t, err, &unknownErr)
+	require.Equal(t, "furlong", unknownErr.Unit)
+}
+
+func TestLookupUnit(t *testing.T) {
+	unit, err := utils.LookupUnit("KPH")
+	require.NoError(t, err)
+	require.Equal(t, "km/h", unit.Symbol)
+	require.Equal(t, utils.DimensionSpeed, unit.Dimension)
+
+	symbols := []string{}
+	for _, u := range utils.UnitsOf(utils.DimensionTemperature) {
+		symbols = append(symbols, u.Symbol)
+	}
+	require.Equal(t, []string{"K", "°C", "°F"}, symbols)
+}
+
+func TestMeasures(t *testing.T) {
+	temperature, err := utils.NewTemperature(21.456, "C")
+	require.NoError(t, err)
+	require.Equal(t, "21.5 °C", temperature.String())
+
+	fahrenheit, err := temperature.In("fahrenheit")
+	require.NoError(t, err)
+	require.Equal(t, "70.6 °F", fahrenheit.String())
+
+	speed, err := utils.NewSpeed(10, "m/s")
+	require.NoError(t, err)
+	knots, err := speed.In("kn")
+	require.NoError(t, err)
+	require.Equal(t, "19.44 kn", knots.Format(2))
+
+	_, err = speed.In("°C")
+	var dimensionErr *utils.DimensionError
+	require.ErrorAs(t, err, &dimensionErr)
+
+	_, err = utils.NewPressure(1000, "mph")
+	require.ErrorAs(t, err, &dimensionErr)
+	require.EqualError(t, err, "mph (speed) is not a pressure unit")
+}
+
+func TestFormatNumber(t *testing.T) {
+	require.Equal(t, "1,234,567.9", utils.FormatNumber(1234567.89, 1, ""))
+	require.Equal(t, "1.234.567,89", utils.FormatNumber(1234567.89, 2, "pt-BR"))
+	require.Equal(t, "-12,0", utils.FormatNumber(-12, 1, "de"))
+	require.Equal(t, "999", utils.FormatNumber(999, 0, "it"))
+	require.Equal(t, "NaN", utils.FormatNumber(math.NaN(), 1, "fr"))
+}